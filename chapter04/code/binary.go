@@ -0,0 +1,216 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+// The binary format is a magic header and version byte, then uvarints for
+// the dimensions, a flags byte for the rules and ready state, the cells
+// packed two bits each, the next ship ID and each ship's ID and cells, and
+// finally the move history with each field of a move as a uvarint.
+const binaryVersion = 2
+
+var binaryMagic = []byte("BSG")
+
+const (
+	flagNoTouching = 1 << iota
+	flagRequireReady
+	flagReady
+)
+
+func (g *Grid) MarshalBinary() ([]byte, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	data := append([]byte(nil), binaryMagic...)
+	data = append(data, binaryVersion)
+	data = binary.AppendUvarint(data, uint64(g.rows))
+	data = binary.AppendUvarint(data, uint64(g.cols))
+
+	var flags byte
+	if g.rules.NoTouching {
+		flags |= flagNoTouching
+	}
+	if g.rules.RequireReady {
+		flags |= flagRequireReady
+	}
+	if g.ready {
+		flags |= flagReady
+	}
+	data = append(data, flags)
+
+	packed := make([]byte, (g.rows*g.cols+3)/4)
+	for row := range g.locations {
+		for col, state := range g.locations[row] {
+			i := row*g.cols + col
+			packed[i/4] |= byte(state) << (2 * (i % 4))
+		}
+	}
+	data = append(data, packed...)
+
+	ids := make([]int, 0, len(g.ships))
+	for id := range g.ships {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	data = binary.AppendUvarint(data, uint64(g.nextID))
+	data = binary.AppendUvarint(data, uint64(len(ids)))
+	for _, id := range ids {
+		data = binary.AppendUvarint(data, uint64(id))
+		data = binary.AppendUvarint(data, uint64(len(g.ships[id])))
+		for _, cell := range g.ships[id] {
+			data = binary.AppendUvarint(data, uint64(cell[0]))
+			data = binary.AppendUvarint(data, uint64(cell[1]))
+		}
+	}
+
+	data = binary.AppendUvarint(data, uint64(len(g.history)))
+	for _, move := range g.history {
+		var hit uint64
+		if move.Hit {
+			hit = 1
+		}
+		for _, field := range []uint64{uint64(move.Kind), uint64(move.Row), uint64(move.Col), uint64(move.Length), uint64(move.Orientation), uint64(move.ShipID), hit} {
+			data = binary.AppendUvarint(data, field)
+		}
+	}
+	return data, nil
+}
+
+func (g *Grid) UnmarshalBinary(data []byte) error {
+	r := &binaryReader{data: data}
+
+	header := r.bytes(len(binaryMagic) + 1)
+	if r.err == nil && (string(header[:len(binaryMagic)]) != string(binaryMagic) || header[len(binaryMagic)] != binaryVersion) {
+		return fmt.Errorf("%w: unrecognised header", ErrCorruptGrid)
+	}
+
+	rows := r.count()
+	cols := r.count()
+	flags := r.bytes(1)
+	if r.err != nil {
+		return r.err
+	}
+
+	if flags[0]&^(flagNoTouching|flagRequireReady|flagReady) != 0 {
+		return fmt.Errorf("%w: unknown flags %#x", ErrCorruptGrid, flags[0])
+	}
+
+	if rows < 1 || cols < 1 || rows*cols > 4*len(r.data) {
+		return fmt.Errorf("%w: %dx%d grid does not fit in %d bytes", ErrCorruptGrid, rows, cols, len(r.data))
+	}
+
+	packed := r.bytes((rows*cols + 3) / 4)
+	cells := makeBoard[CellState](rows, cols)
+	if r.err == nil {
+		for row := range cells {
+			for col := range cells[row] {
+				i := row*cols + col
+				cells[row][col] = CellState(packed[i/4] >> (2 * (i % 4)) & 3)
+			}
+		}
+	}
+
+	nextID := r.count()
+	ships := map[int][][2]int{}
+	for n := r.count(); n > 0 && r.err == nil; n-- {
+		id := r.count()
+		var shipCells [][2]int
+		for length := r.count(); length > 0 && r.err == nil; length-- {
+			shipCells = append(shipCells, [2]int{r.count(), r.count()})
+		}
+
+		if _, ok := ships[id]; ok && r.err == nil {
+			return fmt.Errorf("%w: ship %d listed twice", ErrCorruptGrid, id)
+		}
+		ships[id] = shipCells
+	}
+
+	var history []Move
+	for n := r.count(); n > 0 && r.err == nil; n-- {
+		move := Move{Kind: MoveKind(r.count()), Row: r.count(), Col: r.count(), Length: r.count(), Orientation: Orientation(r.count()), ShipID: r.count()}
+		switch r.count() {
+		case 0:
+		case 1:
+			move.Hit = true
+		default:
+			if r.err == nil {
+				return fmt.Errorf("%w: move %d has an invalid hit flag", ErrCorruptGrid, len(history))
+			}
+		}
+		history = append(history, move)
+	}
+
+	if r.err == nil && len(r.data) > 0 {
+		return fmt.Errorf("%w: %d unexpected trailing bytes", ErrCorruptGrid, len(r.data))
+	}
+	if r.err != nil {
+		return r.err
+	}
+
+	rules := Rules{
+		NoTouching:   flags[0]&flagNoTouching != 0,
+		RequireReady: flags[0]&flagRequireReady != 0,
+	}
+
+	restored, err := restoreGrid(rows, cols, rules, cells, ships, nextID)
+	if err != nil {
+		return err
+	}
+	if err := restored.restoreHistory(history); err != nil {
+		return err
+	}
+	restored.ready = flags[0]&flagReady != 0
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.adopt(restored)
+	return nil
+}
+
+// binaryReader consumes data from the front, remembering the first error so
+// callers can check once after a run of reads.
+type binaryReader struct {
+	data []byte
+	err  error
+}
+
+func (r *binaryReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+
+	if len(r.data) < n {
+		r.err = fmt.Errorf("%w: truncated", ErrCorruptGrid)
+		return nil
+	}
+
+	read := r.data[:n]
+	r.data = r.data[n:]
+	return read
+}
+
+// count reads a uvarint, rejecting values too large to be a real dimension,
+// ship ID or length.
+func (r *binaryReader) count() int {
+	if r.err != nil {
+		return 0
+	}
+
+	value, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err = fmt.Errorf("%w: truncated", ErrCorruptGrid)
+		return 0
+	}
+
+	r.data = r.data[n:]
+	if value > 1<<31 {
+		r.err = fmt.Errorf("%w: value %d too large", ErrCorruptGrid, value)
+		return 0
+	}
+	return int(value)
+}
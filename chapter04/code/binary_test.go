@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*Grid)(nil)
+	_ encoding.BinaryUnmarshaler = (*Grid)(nil)
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	// Arrange
+	original, _ := NewGridWithRules(5, 9, Rules{NoTouching: true, RequireReady: true})
+	original.PlaceShipAt(0, 0, 4, Horizontal)
+	original.PlaceShipAt(2, 8, 3, Vertical)
+	original.PlaceShip(4, 0)
+	original.Ready()
+	original.Fire(0, 3)
+	original.Fire(3, 8)
+	original.Fire(4, 4)
+
+	// Act
+	data, err := original.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error marshalling: %v", err)
+	}
+
+	restored := NewGrid()
+	err = restored.UnmarshalBinary(data)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error unmarshalling: %v", err)
+	}
+
+	if !restored.Equal(original) {
+		t.Errorf("got\n%v\nwant\n%v", restored, original)
+	}
+
+	if restored.rules != original.rules || restored.nextID != original.nextID || restored.ready != original.ready {
+		t.Errorf("got rules %+v and next ID %d, want %+v and %d", restored.rules, restored.nextID, original.rules, original.nextID)
+	}
+}
+
+func TestBinaryRoundTripKeepsHistory(t *testing.T) {
+	// Arrange
+	original := NewGrid()
+	original.PlaceShipAt(0, 0, 2, Horizontal)
+	original.PlaceShipAt(3, 3, 3, Vertical)
+	original.Fire(0, 0)
+	original.Fire(0, 1)
+	original.Fire(6, 6)
+	data, _ := original.MarshalBinary()
+
+	// Act
+	restored := NewGrid()
+	err := restored.UnmarshalBinary(data)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(restored.History(), original.History()) {
+		t.Errorf("got history %+v, want %+v", restored.History(), original.History())
+	}
+
+	if got, want := restored.SunkShips(), original.SunkShips(); len(want) != 1 || !reflect.DeepEqual(got, want) {
+		t.Errorf("got sunk ships %+v, want %+v", got, want)
+	}
+}
+
+func TestBinaryIsCompact(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+
+	// Act
+	data, _ := grid.MarshalBinary()
+
+	// Assert
+	if len(data) != 4+1+1+1+13+1+1+1 {
+		t.Errorf("got %d bytes for an empty 7x7 grid, want 23", len(data))
+	}
+}
+
+func TestUnmarshalBinaryRejectsTruncatedInput(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(1, 1, 3, Horizontal)
+	data, _ := grid.MarshalBinary()
+
+	for n := 0; n < len(data); n++ {
+		// Act
+		err := NewGrid().UnmarshalBinary(data[:n])
+
+		// Assert
+		if !errors.Is(err, ErrCorruptGrid) {
+			t.Errorf("got error %v for %d of %d bytes, want %v", err, n, len(data), ErrCorruptGrid)
+		}
+	}
+}
+
+func TestUnmarshalBinaryRejectsCorruptInput(t *testing.T) {
+	valid := func() []byte {
+		grid, _ := NewGridWithSize(2, 2)
+		grid.PlaceShip(0, 0)
+		data, _ := grid.MarshalBinary()
+		return data
+	}
+
+	tests := []struct {
+		name    string
+		corrupt func([]byte) []byte
+	}{
+		{"bad magic", func(data []byte) []byte {
+			data[0] = 'X'
+			return data
+		}},
+		{"unknown version", func(data []byte) []byte {
+			data[3] = 9
+			return data
+		}},
+		{"huge dimensions", func(data []byte) []byte {
+			return append(data[:4], 0xff, 0xff, 0xff, 0x7f, 0x02, 0x00, 0x00)
+		}},
+		{"ship cell over water", func(data []byte) []byte {
+			data[7] = 0
+			return data
+		}},
+		{"unknown flags", func(data []byte) []byte {
+			data[6] = 0x80
+			return data
+		}},
+		{"move for unknown ship", func(data []byte) []byte {
+			data[len(data)-2] = 9
+			return data
+		}},
+		{"invalid hit flag", func(data []byte) []byte {
+			data[len(data)-1] = 2
+			return data
+		}},
+		{"trailing bytes", func(data []byte) []byte {
+			return append(data, 0)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewGrid().UnmarshalBinary(tt.corrupt(valid()))
+
+			if !errors.Is(err, ErrCorruptGrid) {
+				t.Errorf("got error %v, want %v", err, ErrCorruptGrid)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConcurrentFire(t *testing.T) {
+	// Arrange
+	grid, _ := NewGridWithSize(10, 10)
+	for row := 0; row < 10; row += 2 {
+		grid.PlaceShipAt(row, 0, 5, Horizontal)
+	}
+
+	var wg sync.WaitGroup
+	var hits sync.Map
+
+	// Act
+	for row := 0; row < 10; row++ {
+		for col := 0; col < 10; col++ {
+			wg.Add(2)
+			go func(row int, col int) {
+				defer wg.Done()
+				if result, err := grid.Fire(row, col); err == nil && result.Hit {
+					hits.Store([2]int{row, col}, true)
+				}
+			}(row, col)
+			go func(row int, col int) {
+				defer wg.Done()
+				grid.CellAt(row, col)
+				grid.AllShipsSunk()
+				_ = grid.String()
+			}(row, col)
+		}
+	}
+	wg.Wait()
+
+	// Assert
+	if got := grid.ShotCount(); got != 100 {
+		t.Errorf("got %d shots recorded, want 100", got)
+	}
+
+	count := 0
+	hits.Range(func(_, _ any) bool {
+		count++
+		return true
+	})
+
+	if count != 25 || !grid.AllShipsSunk() {
+		t.Errorf("got %d hits, want 25 and the whole fleet sunk", count)
+	}
+}
+
+func TestConcurrentFireAtSameCell(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShip(3, 3)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	accepted := 0
+
+	// Act
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := grid.Fire(3, 3); err == nil {
+				mu.Lock()
+				accepted++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	if accepted != 1 {
+		t.Errorf("got %d shots accepted at the same cell, want 1", accepted)
+	}
+}
+
+func TestForEachCellCallbackCanReadWhileFireWaits(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShip(0, 0)
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	// Act
+	go func() {
+		defer close(done)
+		grid.ForEachCell(func(row int, col int, state CellState) {
+			if row == 0 && col == 0 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					grid.Fire(0, 0)
+				}()
+				time.Sleep(10 * time.Millisecond)
+			}
+			grid.CellAt(row, col)
+		})
+		wg.Wait()
+	}()
+
+	// Assert
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("ForEachCell deadlocked while a Fire was waiting")
+	}
+
+	if state, _ := grid.CellAt(0, 0); state != Hit {
+		t.Errorf("got %v at (0, 0), want %v", state, Hit)
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxColumnLetters bounds how long a column label may be, which keeps the
+// column index well inside an int. Six letters reach column ZZZZZZ.
+const maxColumnLetters = 6
+
+// ParseCoordinate turns a label such as "B5" into zero-based row and column
+// indices on the standard ROWS by COLUMNS board. The column letter comes first
+// and is case-insensitive; the row number counts from 1.
+func ParseCoordinate(s string) (row int, col int, err error) {
+	row, col, err = parseCoordinate(s)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if row < 0 || row >= ROWS || col < 0 || col >= COLUMNS {
+		return 0, 0, fmt.Errorf("%q: %w", s, ErrOutOfBounds)
+	}
+	return row, col, nil
+}
+
+func FormatCoordinate(row int, col int) string {
+	return columnLabel(col) + strconv.Itoa(row+1)
+}
+
+func parseCoordinate(s string) (row int, col int, err error) {
+	label := strings.ToUpper(strings.TrimSpace(s))
+
+	letters := 0
+	for letters < len(label) && label[letters] >= 'A' && label[letters] <= 'Z' {
+		if letters < maxColumnLetters {
+			col = col*26 + int(label[letters]-'A'+1)
+		}
+		letters++
+	}
+
+	number, err := strconv.Atoi(label[letters:])
+	if letters == 0 || err != nil || number < 1 || label[letters] == '+' {
+		return 0, 0, fmt.Errorf("%q: %w", s, ErrInvalidCoordinate)
+	}
+
+	if letters > maxColumnLetters {
+		return 0, 0, fmt.Errorf("%q: %w", s, ErrOutOfBounds)
+	}
+
+	return number - 1, col - 1, nil
+}
+
+func (g *Grid) FireAt(coord string) (FireResult, error) {
+	row, col, err := parseCoordinate(coord)
+	if err != nil {
+		return FireResult{}, err
+	}
+
+	return g.Fire(row, col)
+}
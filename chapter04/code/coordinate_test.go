@@ -0,0 +1,152 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseCoordinate(t *testing.T) {
+	tests := []struct {
+		input string
+		row   int
+		col   int
+	}{
+		{"A1", 0, 0},
+		{"B5", 4, 1},
+		{"c7", 6, 2},
+		{"G7", 6, 6},
+		{" d4 ", 3, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			row, col, err := ParseCoordinate(tt.input)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if row != tt.row || col != tt.col {
+				t.Errorf("got (%d, %d), want (%d, %d)", row, col, tt.row, tt.col)
+			}
+		})
+	}
+}
+
+func TestParseCoordinateRejectsMalformedInput(t *testing.T) {
+	for _, input := range []string{"", "5B", "B", "7", "B0", "B-1", "B+1", "B5x", "?3"} {
+		t.Run(input, func(t *testing.T) {
+			_, _, err := ParseCoordinate(input)
+
+			if !errors.Is(err, ErrInvalidCoordinate) {
+				t.Errorf("got error %v, want %v", err, ErrInvalidCoordinate)
+			}
+		})
+	}
+}
+
+func TestParseCoordinateRejectsOffBoard(t *testing.T) {
+	for _, input := range []string{"Z9", "ZZZZZZZZZZZZZZ1", "H1", "A8"} {
+		t.Run(input, func(t *testing.T) {
+			_, _, err := ParseCoordinate(input)
+
+			if !errors.Is(err, ErrOutOfBounds) {
+				t.Errorf("got error %v, want %v", err, ErrOutOfBounds)
+			}
+		})
+	}
+}
+
+func TestFormatCoordinate(t *testing.T) {
+	// Arrange
+	row := 4
+	col := 1
+
+	// Act
+	got := FormatCoordinate(row, col)
+
+	// Assert
+	if got != "B5" {
+		t.Errorf("got %q, want %q", got, "B5")
+	}
+}
+
+func TestCoordinateRoundTrip(t *testing.T) {
+	for row := 0; row < ROWS; row++ {
+		for col := 0; col < COLUMNS; col++ {
+			label := FormatCoordinate(row, col)
+
+			gotRow, gotCol, err := ParseCoordinate(label)
+
+			if err != nil || gotRow != row || gotCol != col {
+				t.Errorf("%q parsed to (%d, %d, %v), want (%d, %d)", label, gotRow, gotCol, err, row, col)
+			}
+		}
+	}
+}
+
+func TestFireAtHitsShip(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShip(3, 3)
+
+	// Act
+	result, err := grid.FireAt("D4")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.Hit {
+		t.Error("Shot at ship was not a hit")
+	}
+
+	if _, err := grid.Fire(3, 3); !errors.Is(err, ErrAlreadyFired) {
+		t.Errorf("got error %v firing again, want %v", err, ErrAlreadyFired)
+	}
+}
+
+func TestFireAtUsesGridDimensions(t *testing.T) {
+	// Arrange
+	grid, _ := NewGridWithSize(10, 10)
+	grid.PlaceShip(9, 9)
+
+	// Act
+	result, err := grid.FireAt("J10")
+
+	// Assert
+	if err != nil || !result.Hit {
+		t.Errorf("got (%+v, %v), want a hit on a 10x10 grid", result, err)
+	}
+}
+
+func TestFireAtRejectsGarbage(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+
+	// Act
+	_, err := grid.FireAt("not a coordinate")
+
+	// Assert
+	if !errors.Is(err, ErrInvalidCoordinate) {
+		t.Errorf("got error %v, want %v", err, ErrInvalidCoordinate)
+	}
+}
+
+func TestFireAtRejectsOffBoard(t *testing.T) {
+	for _, input := range []string{"Z9", "ZZZZZZZZZZZZZZ1"} {
+		t.Run(input, func(t *testing.T) {
+			// Arrange
+			grid := NewGrid()
+
+			// Act
+			_, err := grid.FireAt(input)
+
+			// Assert
+			if !errors.Is(err, ErrOutOfBounds) {
+				t.Errorf("got error %v, want %v", err, ErrOutOfBounds)
+			}
+		})
+	}
+}
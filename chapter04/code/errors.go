@@ -0,0 +1,24 @@
+package main
+
+import "errors"
+
+// Every error returned by the package wraps one of these, so callers can
+// check for them with errors.Is.
+var (
+	ErrInvalidSize       = errors.New("grid dimensions must be positive")
+	ErrInvalidLength     = errors.New("ship length must be at least one")
+	ErrOutOfBounds       = errors.New("coordinates out of bounds")
+	ErrAlreadyFired      = errors.New("already fired at these coordinates")
+	ErrOverlap           = errors.New("ship overlaps another ship")
+	ErrAdjacent          = errors.New("ship touches another ship")
+	ErrNoSuchShip        = errors.New("no such ship")
+	ErrInvalidCoordinate = errors.New("invalid coordinate")
+	ErrFleetDoesNotFit   = errors.New("fleet does not fit on the grid")
+	ErrWrongFleet        = errors.New("fleet does not match the required ships")
+	ErrUnfairLayout      = errors.New("fleet layout breaks the validation options")
+	ErrCorruptGrid       = errors.New("corrupt grid data")
+	ErrInvalidLayout     = errors.New("invalid grid layout")
+	ErrNothingToUndo     = errors.New("no moves to undo")
+	ErrNotReady          = errors.New("grid is not ready for firing")
+	ErrLocked            = errors.New("fleet is locked once the grid is ready")
+)
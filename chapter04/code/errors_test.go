@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrorsWrapSentinels(t *testing.T) {
+	grid := NewGrid()
+	grid.PlaceShipAt(0, 0, 2, Horizontal)
+	grid.Fire(0, 0)
+
+	tests := []struct {
+		name string
+		err  func() error
+		want error
+	}{
+		{"out of bounds placement", func() error {
+			_, err := grid.PlaceShipAt(6, 6, 2, Vertical)
+			return err
+		}, ErrOutOfBounds},
+		{"out of bounds shot", func() error {
+			_, err := grid.Fire(7, 0)
+			return err
+		}, ErrOutOfBounds},
+		{"out of bounds inspection", func() error {
+			_, err := grid.CellAt(0, -1)
+			return err
+		}, ErrOutOfBounds},
+		{"overlapping placement", func() error {
+			_, err := grid.PlaceShipAt(0, 1, 2, Vertical)
+			return err
+		}, ErrOverlap},
+		{"repeated shot", func() error {
+			_, err := grid.Fire(0, 0)
+			return err
+		}, ErrAlreadyFired},
+		{"unknown ship", func() error {
+			return grid.RemoveShip(99)
+		}, ErrNoSuchShip},
+		{"zero length ship", func() error {
+			_, err := grid.PlaceShipAt(3, 3, 0, Horizontal)
+			return err
+		}, ErrInvalidLength},
+		{"bad coordinate label", func() error {
+			_, err := grid.FireAt("5B")
+			return err
+		}, ErrInvalidCoordinate},
+		{"bad grid size", func() error {
+			_, err := NewGridWithSize(0, 0)
+			return err
+		}, ErrInvalidSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.err()
+
+			if !errors.Is(err, tt.want) {
+				t.Errorf("got error %v, want it to wrap %v", err, tt.want)
+			}
+
+			if err == tt.want {
+				t.Errorf("got bare %v, want it wrapped with detail", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+const maxPlacementAttempts = 1000
+
+// StandardFleet is the classic line-up: a carrier, a battleship, a cruiser,
+// a submarine and a destroyer.
+var StandardFleet = []int{5, 4, 3, 3, 2}
+
+type ShipSpec struct {
+	Row         int
+	Col         int
+	Length      int
+	Orientation Orientation
+}
+
+// ValidationOptions constrain how a fleet may be laid out. MinSpacing is the
+// number of empty cells required between any two ships, in any direction
+// including diagonally. MaxShipsPerQuadrant limits how many ships may touch
+// each quarter of the board. The zero value imposes no constraints.
+type ValidationOptions struct {
+	MinSpacing          int
+	MaxShipsPerQuadrant int
+}
+
+type ShipStatus struct {
+	ID     int
+	Length int
+	Hits   int
+	Sunk   bool
+}
+
+// PlaceFleetRandomly places a ship of each length at a random position and
+// orientation chosen by rng. Each ship gets a bounded number of attempts; if
+// one cannot be placed, every ship placed by this call is removed again.
+func (g *Grid) PlaceFleetRandomly(sizes []int, rng *rand.Rand) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.ready {
+		return ErrLocked
+	}
+
+	placed := make([]int, 0, len(sizes))
+
+	for _, length := range sizes {
+		id, err := g.placeShipRandomly(length, rng)
+		if err != nil {
+			g.removeShips(placed)
+			return err
+		}
+		placed = append(placed, id)
+	}
+	return nil
+}
+
+// PlaceShips places every ship in specs and returns their IDs in the same
+// order. It is all or nothing: if any spec cannot be placed, the ships placed
+// before it are removed again and the error gives the index of the bad spec.
+func (g *Grid) PlaceShips(specs []ShipSpec) ([]int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ids := make([]int, 0, len(specs))
+	for i, spec := range specs {
+		id, err := g.placeShipAt(spec.Row, spec.Col, spec.Length, spec.Orientation)
+		if err != nil {
+			g.removeShips(ids)
+			return nil, fmt.Errorf("ship spec %d: %w", i, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (g *Grid) removeShips(ids []int) {
+	for _, id := range ids {
+		g.removeShip(id)
+	}
+}
+
+func (g *Grid) placeShipRandomly(length int, rng *rand.Rand) (int, error) {
+	if length < 1 {
+		return 0, fmt.Errorf("length %d: %w", length, ErrInvalidLength)
+	}
+
+	for attempt := 0; attempt < maxPlacementAttempts; attempt++ {
+		orientation := Orientation(rng.Intn(2))
+		id, err := g.placeShipAt(rng.Intn(g.rows), rng.Intn(g.cols), length, orientation)
+		if err == nil {
+			return id, nil
+		}
+	}
+	return 0, fmt.Errorf("ship of length %d after %d attempts: %w", length, maxPlacementAttempts, ErrFleetDoesNotFit)
+}
+
+// FleetStatus reports the damage to every placed ship, ordered by ship ID.
+func (g *Grid) FleetStatus() []ShipStatus {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	statuses := make([]ShipStatus, 0, len(g.ships))
+	for id, cells := range g.ships {
+		status := ShipStatus{ID: id, Length: len(cells)}
+		for _, cell := range cells {
+			if g.locations[cell[0]][cell[1]] == Hit {
+				status.Hits++
+			}
+		}
+		status.Sunk = status.Hits == status.Length
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ID < statuses[j].ID
+	})
+	return statuses
+}
+
+func (g *Grid) TotalShips() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return len(g.ships)
+}
+
+func (g *Grid) ShipsRemaining() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	remaining := 0
+	for id := range g.ships {
+		if !g.isSunk(id) {
+			remaining++
+		}
+	}
+	return remaining
+}
+
+// ValidateFleet checks that the ships placed on the grid have exactly the
+// lengths in required, in any order. The error lists the lengths that are
+// missing and the lengths that are extra.
+func (g *Grid) ValidateFleet(required []int) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	counts := map[int]int{}
+	for _, length := range required {
+		counts[length]++
+	}
+	for _, cells := range g.ships {
+		counts[len(cells)]--
+	}
+
+	lengths := make([]int, 0, len(counts))
+	for length := range counts {
+		lengths = append(lengths, length)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(lengths)))
+
+	var missing, extra []int
+	for _, length := range lengths {
+		for n := counts[length]; n > 0; n-- {
+			missing = append(missing, length)
+		}
+		for n := counts[length]; n < 0; n++ {
+			extra = append(extra, length)
+		}
+	}
+
+	if len(missing) > 0 || len(extra) > 0 {
+		return fmt.Errorf("%w: missing %v, extra %v", ErrWrongFleet, missing, extra)
+	}
+	return nil
+}
+
+// Validate checks the placed fleet against opts, describing the first
+// constraint that is broken.
+func (g *Grid) Validate(opts ValidationOptions) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	ids := make([]int, 0, len(g.ships))
+	for id := range g.ships {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	if opts.MinSpacing > 0 {
+		for i, a := range ids {
+			for _, b := range ids[i+1:] {
+				if gap := shipGap(g.ships[a], g.ships[b]); gap < opts.MinSpacing {
+					return fmt.Errorf("%w: ships %d and %d are %d cells apart, want at least %d",
+						ErrUnfairLayout, a, b, gap, opts.MinSpacing)
+				}
+			}
+		}
+	}
+
+	if opts.MaxShipsPerQuadrant > 0 {
+		var quadrants [4]int
+		for _, id := range ids {
+			var touched [4]bool
+			for _, cell := range g.ships[id] {
+				touched[g.quadrant(cell[0], cell[1])] = true
+			}
+			for q, ok := range touched {
+				if ok {
+					quadrants[q]++
+				}
+			}
+		}
+
+		for q, count := range quadrants {
+			if count > opts.MaxShipsPerQuadrant {
+				return fmt.Errorf("%w: %d ships in quadrant %d, want at most %d",
+					ErrUnfairLayout, count, q+1, opts.MaxShipsPerQuadrant)
+			}
+		}
+	}
+	return nil
+}
+
+// shipGap counts the empty cells between the closest cells of two ships.
+func shipGap(a [][2]int, b [][2]int) int {
+	gap := -1
+	for _, p := range a {
+		for _, q := range b {
+			distance := max(abs(p[0]-q[0]), abs(p[1]-q[1])) - 1
+			if gap < 0 || distance < gap {
+				gap = distance
+			}
+		}
+	}
+	return gap
+}
+
+// quadrant numbers the quarters of the board 0 to 3 in reading order. On an
+// odd-sized board the middle row and column belong to the top and left.
+func (g *Grid) quadrant(row int, col int) int {
+	q := 0
+	if row >= (g.rows+1)/2 {
+		q += 2
+	}
+	if col >= (g.cols+1)/2 {
+		q++
+	}
+	return q
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
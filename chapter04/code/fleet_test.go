@@ -0,0 +1,368 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPlaceFleetRandomlyIsReproducible(t *testing.T) {
+	// Arrange
+	sizes := []int{5, 4, 3, 3, 2}
+	first := NewGrid()
+	second := NewGrid()
+
+	// Act
+	errFirst := first.PlaceFleetRandomly(sizes, rand.New(rand.NewSource(42)))
+	errSecond := second.PlaceFleetRandomly(sizes, rand.New(rand.NewSource(42)))
+
+	// Assert
+	if errFirst != nil || errSecond != nil {
+		t.Fatalf("unexpected errors: %v, %v", errFirst, errSecond)
+	}
+
+	if first.String() != second.String() {
+		t.Errorf("same seed gave different layouts:\n%s\n%s", first, second)
+	}
+}
+
+func TestPlaceFleetRandomlyPlacesEveryShip(t *testing.T) {
+	// Arrange
+	sizes := []int{5, 4, 3, 3, 2}
+	grid := NewGrid()
+
+	// Act
+	err := grid.PlaceFleetRandomly(sizes, rand.New(rand.NewSource(7)))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(grid.ships) != len(sizes) {
+		t.Fatalf("got %d ships, want %d", len(grid.ships), len(sizes))
+	}
+
+	occupied := 0
+	for row := 0; row < ROWS; row++ {
+		for col := 0; col < COLUMNS; col++ {
+			if grid.isShipPresent(row, col) {
+				occupied++
+			}
+		}
+	}
+
+	if occupied != 17 {
+		t.Errorf("got %d occupied cells, want 17", occupied)
+	}
+}
+
+func TestPlaceFleetRandomlyRejectsImpossibleFleet(t *testing.T) {
+	// Arrange
+	grid, _ := NewGridWithSize(3, 3)
+	grid.PlaceShip(1, 1)
+
+	// Act
+	err := grid.PlaceFleetRandomly([]int{3, 3, 3}, rand.New(rand.NewSource(1)))
+
+	// Assert
+	if !errors.Is(err, ErrFleetDoesNotFit) {
+		t.Fatalf("got error %v, want %v", err, ErrFleetDoesNotFit)
+	}
+
+	if len(grid.ships) != 1 {
+		t.Errorf("got %d ships after failed placement, want only the original", len(grid.ships))
+	}
+}
+
+func TestFleetStatus(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	cruiser, _ := grid.PlaceShipAt(0, 0, 3, Horizontal)
+	destroyer, _ := grid.PlaceShipAt(2, 5, 2, Vertical)
+	grid.Fire(0, 1)
+	grid.Fire(2, 5)
+	grid.Fire(3, 5)
+	grid.Fire(6, 6)
+
+	// Act
+	got := grid.FleetStatus()
+
+	// Assert
+	want := []ShipStatus{
+		{ID: cruiser, Length: 3, Hits: 1, Sunk: false},
+		{ID: destroyer, Length: 2, Hits: 2, Sunk: true},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestFleetStatusOfEmptyGrid(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+
+	// Act
+	got := grid.FleetStatus()
+
+	// Assert
+	if len(got) != 0 {
+		t.Errorf("got %+v, want no ships", got)
+	}
+}
+
+func TestValidateFleetAcceptsStandardFleet(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(0, 0, 2, Horizontal)
+	grid.PlaceShipAt(1, 0, 3, Horizontal)
+	grid.PlaceShipAt(2, 0, 5, Horizontal)
+	grid.PlaceShipAt(3, 0, 3, Horizontal)
+	grid.PlaceShipAt(4, 0, 4, Horizontal)
+
+	// Act
+	err := grid.ValidateFleet(StandardFleet)
+
+	// Assert
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateFleetReportsMissingAndExtraShips(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(0, 0, 5, Horizontal)
+	grid.PlaceShipAt(1, 0, 4, Horizontal)
+	grid.PlaceShipAt(2, 0, 3, Horizontal)
+	grid.PlaceShipAt(3, 0, 2, Horizontal)
+	grid.PlaceShipAt(4, 0, 2, Horizontal)
+
+	// Act
+	err := grid.ValidateFleet(StandardFleet)
+
+	// Assert
+	if !errors.Is(err, ErrWrongFleet) {
+		t.Fatalf("got error %v, want %v", err, ErrWrongFleet)
+	}
+
+	if !strings.Contains(err.Error(), "missing [3], extra [2]") {
+		t.Errorf("got error %q, want it to list the missing and extra ships", err)
+	}
+}
+
+func TestValidateFleetOnEmptyGrid(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+
+	// Act
+	err := grid.ValidateFleet(StandardFleet)
+
+	// Assert
+	if !strings.Contains(fmt.Sprint(err), "missing [5 4 3 3 2], extra []") {
+		t.Errorf("got error %v, want every ship missing", err)
+	}
+}
+
+func TestShipsRemaining(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(0, 0, 2, Horizontal)
+	grid.PlaceShipAt(2, 0, 3, Horizontal)
+	grid.PlaceShipAt(4, 0, 4, Horizontal)
+
+	// Act
+	grid.Fire(0, 0)
+	grid.Fire(0, 1)
+	grid.Fire(2, 0)
+
+	// Assert
+	if got := grid.ShipsRemaining(); got != 2 {
+		t.Errorf("got %d ships remaining, want 2", got)
+	}
+
+	if got := grid.TotalShips(); got != 3 {
+		t.Errorf("got %d ships in total, want 3", got)
+	}
+}
+
+func TestShipsRemainingOnEmptyGrid(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+
+	// Act
+	remaining := grid.ShipsRemaining()
+	total := grid.TotalShips()
+
+	// Assert
+	if remaining != 0 || total != 0 {
+		t.Errorf("got %d of %d ships remaining, want 0 of 0", remaining, total)
+	}
+}
+
+func TestPlaceFleetRandomlyAfterReady(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.Ready()
+
+	// Act
+	err := grid.PlaceFleetRandomly(StandardFleet, rand.New(rand.NewSource(1)))
+
+	// Assert
+	if !errors.Is(err, ErrLocked) {
+		t.Errorf("got error %v, want %v", err, ErrLocked)
+	}
+}
+
+func TestPlaceShips(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	specs := []ShipSpec{
+		{Row: 0, Col: 0, Length: 5, Orientation: Horizontal},
+		{Row: 2, Col: 6, Length: 4, Orientation: Vertical},
+		{Row: 6, Col: 0, Length: 3, Orientation: Horizontal},
+	}
+
+	// Act
+	ids, err := grid.PlaceShips(specs)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(ids) != len(specs) {
+		t.Fatalf("got %d IDs, want %d", len(ids), len(specs))
+	}
+
+	for i, spec := range specs {
+		cells, _ := grid.ShipCells(ids[i])
+		if len(cells) != spec.Length || cells[0] != [2]int{spec.Row, spec.Col} {
+			t.Errorf("ship %d occupies %v, want it to match spec %+v", ids[i], cells, spec)
+		}
+	}
+}
+
+func TestPlaceShipsIsAllOrNothing(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShip(6, 6)
+	before := grid.Clone()
+	specs := []ShipSpec{
+		{Row: 1, Col: 1, Length: 4, Orientation: Horizontal},
+		{Row: 0, Col: 2, Length: 3, Orientation: Vertical},
+		{Row: 4, Col: 0, Length: 2, Orientation: Horizontal},
+	}
+
+	// Act
+	ids, err := grid.PlaceShips(specs)
+
+	// Assert
+	if !errors.Is(err, ErrOverlap) {
+		t.Fatalf("got error %v, want %v", err, ErrOverlap)
+	}
+
+	if !strings.Contains(err.Error(), "ship spec 1") {
+		t.Errorf("got error %q, want it to name spec 1", err)
+	}
+
+	if ids != nil {
+		t.Errorf("got IDs %v for a rejected batch", ids)
+	}
+
+	if !grid.Equal(before) || len(grid.History()) != 1 {
+		t.Errorf("rejected batch changed the grid:\n%v", grid)
+	}
+}
+
+func TestValidateRejectsClusteredFleet(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(0, 0, 3, Horizontal)
+	grid.PlaceShipAt(1, 0, 2, Horizontal)
+	grid.PlaceShipAt(3, 0, 2, Vertical)
+
+	// Act
+	err := grid.Validate(ValidationOptions{MinSpacing: 1})
+
+	// Assert
+	if !errors.Is(err, ErrUnfairLayout) {
+		t.Fatalf("got error %v, want %v", err, ErrUnfairLayout)
+	}
+
+	if !strings.Contains(err.Error(), "ships 1 and 2 are 0 cells apart") {
+		t.Errorf("got error %q, want it to name the ships that are too close", err)
+	}
+}
+
+func TestValidateAcceptsSpreadOutFleet(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(0, 0, 3, Horizontal)
+	grid.PlaceShipAt(0, 5, 2, Vertical)
+	grid.PlaceShipAt(5, 0, 2, Vertical)
+	grid.PlaceShipAt(6, 4, 3, Horizontal)
+
+	// Act
+	err := grid.Validate(ValidationOptions{MinSpacing: 1, MaxShipsPerQuadrant: 1})
+
+	// Assert
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateRejectsCrowdedQuadrant(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShip(0, 0)
+	grid.PlaceShip(0, 2)
+	grid.PlaceShip(2, 0)
+	grid.PlaceShip(6, 6)
+
+	// Act
+	err := grid.Validate(ValidationOptions{MaxShipsPerQuadrant: 2})
+
+	// Assert
+	if !errors.Is(err, ErrUnfairLayout) {
+		t.Fatalf("got error %v, want %v", err, ErrUnfairLayout)
+	}
+
+	if !strings.Contains(err.Error(), "3 ships in quadrant 1") {
+		t.Errorf("got error %q, want it to name the crowded quadrant", err)
+	}
+}
+
+func TestValidateCountsShipInEveryQuadrantItTouches(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(4, 0, 7, Horizontal)
+	grid.PlaceShip(6, 6)
+
+	// Act
+	err := grid.Validate(ValidationOptions{MaxShipsPerQuadrant: 1})
+
+	// Assert
+	if !errors.Is(err, ErrUnfairLayout) {
+		t.Errorf("got error %v, want %v", err, ErrUnfairLayout)
+	}
+}
+
+func TestValidateWithDefaultOptions(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(0, 0, 3, Horizontal)
+	grid.PlaceShipAt(1, 0, 3, Horizontal)
+
+	// Act
+	err := grid.Validate(ValidationOptions{})
+
+	// Assert
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
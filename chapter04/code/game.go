@@ -0,0 +1,20 @@
+package main
+
+// Winner referees a two-player game where a is player 1's grid and b is
+// player 2's. A player wins when the other player's fleet is sunk, so it
+// returns 1 when b is sunk and 2 when a is sunk. If neither or both fleets
+// are sunk there is no decisive winner and it returns 0, false. A nil grid
+// counts as a fleet that has not been sunk.
+func Winner(a *Grid, b *Grid) (int, bool) {
+	aSunk := a != nil && a.AllShipsSunk()
+	bSunk := b != nil && b.AllShipsSunk()
+
+	switch {
+	case bSunk && !aSunk:
+		return 1, true
+	case aSunk && !bSunk:
+		return 2, true
+	default:
+		return 0, false
+	}
+}
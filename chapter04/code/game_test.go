@@ -0,0 +1,57 @@
+package main
+
+import "testing"
+
+func TestWinner(t *testing.T) {
+	tests := []struct {
+		name       string
+		sinkFirst  bool
+		sinkSecond bool
+		nilFirst   bool
+		nilSecond  bool
+		wantPlayer int
+		wantOver   bool
+	}{
+		{"game in progress", false, false, false, false, 0, false},
+		{"player one sinks player two", false, true, false, false, 1, true},
+		{"player two sinks player one", true, false, false, false, 2, true},
+		{"both fleets sunk", true, true, false, false, 0, false},
+		{"player one missing", false, true, true, false, 1, true},
+		{"player two missing", true, false, false, true, 2, true},
+		{"both players missing", false, false, true, true, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			first := NewGrid()
+			first.PlaceShipAt(0, 0, 2, Horizontal)
+			second := NewGrid()
+			second.PlaceShipAt(4, 4, 2, Vertical)
+			second.Fire(4, 4)
+
+			if tt.sinkFirst {
+				first.Fire(0, 0)
+				first.Fire(0, 1)
+			}
+			if tt.sinkSecond {
+				second.Fire(5, 4)
+			}
+
+			if tt.nilFirst {
+				first = nil
+			}
+			if tt.nilSecond {
+				second = nil
+			}
+
+			// Act
+			player, over := Winner(first, second)
+
+			// Assert
+			if player != tt.wantPlayer || over != tt.wantOver {
+				t.Errorf("got (%d, %v), want (%d, %v)", player, over, tt.wantPlayer, tt.wantOver)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// Hash returns a SHA-256 digest of the same state Equal compares: the
+// dimensions, every cell's state and the ship occupying it. Equal grids
+// always hash the same.
+func (g *Grid) Hash() [32]byte {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	data := binary.AppendUvarint(nil, uint64(g.rows))
+	data = binary.AppendUvarint(data, uint64(g.cols))
+	for row := range g.locations {
+		for col, state := range g.locations[row] {
+			data = binary.AppendUvarint(data, uint64(state))
+			data = binary.AppendUvarint(data, uint64(g.shipIDs[row][col]))
+		}
+	}
+	return sha256.Sum256(data)
+}
@@ -0,0 +1,85 @@
+package main
+
+import "testing"
+
+func TestHashPreservedByClone(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(1, 1, 3, Horizontal)
+	grid.Fire(1, 2)
+
+	// Act
+	clone := grid.Clone()
+
+	// Assert
+	if grid.Hash() != clone.Hash() {
+		t.Error("Clone hashed differently from the original")
+	}
+}
+
+func TestHashChangesWithState(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(1, 1, 3, Horizontal)
+	before := grid.Hash()
+
+	// Act
+	grid.Fire(5, 5)
+
+	// Assert
+	if grid.Hash() == before {
+		t.Error("Hash unchanged after firing")
+	}
+}
+
+func TestHashDistinguishesLayouts(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func() *Grid
+	}{
+		{"transposed dimensions", func() *Grid {
+			grid, _ := NewGridWithSize(3, 2)
+			return grid
+		}},
+		{"one ship split in two", func() *Grid {
+			grid, _ := NewGridWithSize(2, 3)
+			grid.PlaceShip(0, 0)
+			grid.PlaceShip(0, 1)
+			return grid
+		}},
+		{"hit instead of intact", func() *Grid {
+			grid, _ := NewGridWithSize(2, 3)
+			grid.PlaceShipAt(0, 0, 2, Horizontal)
+			grid.Fire(0, 0)
+			return grid
+		}},
+	}
+
+	reference, _ := NewGridWithSize(2, 3)
+	reference.PlaceShipAt(0, 0, 2, Horizontal)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.build().Hash() == reference.Hash() {
+				t.Error("Different grids hashed the same")
+			}
+		})
+	}
+}
+
+func TestHashIgnoresHowLayoutWasReached(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(0, 0, 2, Horizontal)
+	grid.Fire(6, 6)
+
+	parsed, _ := ParseGrid(grid.String())
+
+	// Act
+	equal := grid.Equal(parsed)
+
+	// Assert
+	if !equal || grid.Hash() != parsed.Hash() {
+		t.Errorf("got equal %v with hashes %x and %x", equal, grid.Hash(), parsed.Hash())
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import "fmt"
+
+type MoveKind int
+
+const (
+	PlaceMove MoveKind = iota
+	FireMove
+)
+
+// Move records one action taken on a grid. A PlaceMove fills in the ship's
+// origin, Length, Orientation and ShipID; a FireMove fills in the target and
+// whether it Hit.
+type Move struct {
+	Kind        MoveKind
+	Row         int
+	Col         int
+	Length      int
+	Orientation Orientation
+	ShipID      int
+	Hit         bool
+}
+
+// SunkInfo records that a ship went down on the given shot, counting the
+// grid's shots from 1.
+type SunkInfo struct {
+	ShipID int
+	Shot   int
+}
+
+func (g *Grid) History() []Move {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return append([]Move(nil), g.history...)
+}
+
+// SunkShips lists the ships that have been sunk in the order they went down,
+// worked out by replaying the shots in the history. Only recorded shots count,
+// so a grid from ParseGrid can have every ship sunk and still report none.
+func (g *Grid) SunkShips() []SunkInfo {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var sunk []SunkInfo
+	hits := map[int]int{}
+	shot := 0
+	for _, move := range g.history {
+		if move.Kind != FireMove {
+			continue
+		}
+		shot++
+
+		id := g.shipIDs[move.Row][move.Col]
+		if !move.Hit || id == 0 {
+			continue
+		}
+
+		hits[id]++
+		if hits[id] == len(g.ships[id]) {
+			sunk = append(sunk, SunkInfo{ShipID: id, Shot: shot})
+		}
+	}
+	return sunk
+}
+
+// streak counts the hits at the end of the history, stopping at the most
+// recent miss.
+func (g *Grid) streak() int {
+	streak := 0
+	for i := len(g.history) - 1; i >= 0; i-- {
+		move := g.history[i]
+		if move.Kind != FireMove {
+			continue
+		}
+		if !move.Hit {
+			break
+		}
+		streak++
+	}
+	return streak
+}
+
+// Undo reverses the most recent move: a shot is un-fired, leaving the ship or
+// water that is still there, and a placement is taken off the grid.
+func (g *Grid) Undo() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if len(g.history) == 0 {
+		return ErrNothingToUndo
+	}
+
+	last := g.history[len(g.history)-1]
+	switch last.Kind {
+	case PlaceMove:
+		if g.ready {
+			return ErrLocked
+		}
+		g.clearShip(last.ShipID)
+	case FireMove:
+		if last.Hit && g.shipIDs[last.Row][last.Col] != 0 {
+			g.locations[last.Row][last.Col] = Ship
+		} else {
+			g.locations[last.Row][last.Col] = Empty
+		}
+	default:
+		return fmt.Errorf("%w: unknown move kind %d", ErrCorruptGrid, last.Kind)
+	}
+
+	g.history = g.history[:len(g.history)-1]
+	return nil
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestHistoryRecordsMovesInOrder(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+
+	// Act
+	id, _ := grid.PlaceShipAt(1, 1, 3, Vertical)
+	grid.Fire(2, 1)
+	grid.Fire(0, 0)
+	grid.Fire(0, 0)
+
+	// Assert
+	want := []Move{
+		{Kind: PlaceMove, Row: 1, Col: 1, Length: 3, Orientation: Vertical, ShipID: id},
+		{Kind: FireMove, Row: 2, Col: 1, Hit: true},
+		{Kind: FireMove, Row: 0, Col: 0, Hit: false},
+	}
+
+	if got := grid.History(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestUndoBackToEmptyGrid(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(1, 1, 3, Vertical)
+	afterPlacing := grid.Clone()
+	grid.Fire(2, 1)
+	afterHit := grid.Clone()
+	grid.Fire(0, 0)
+
+	// Act and Assert
+	if err := grid.Undo(); err != nil || !grid.Equal(afterHit) {
+		t.Fatalf("undoing the miss gave %v:\n%v", err, grid)
+	}
+
+	if err := grid.Undo(); err != nil || !grid.Equal(afterPlacing) {
+		t.Fatalf("undoing the hit gave %v:\n%v", err, grid)
+	}
+
+	if err := grid.Undo(); err != nil || !grid.Equal(NewGrid()) {
+		t.Fatalf("undoing the placement gave %v:\n%v", err, grid)
+	}
+
+	if len(grid.History()) != 0 {
+		t.Errorf("got history %+v, want it empty", grid.History())
+	}
+}
+
+func TestUndoWithEmptyHistory(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+
+	// Act
+	err := grid.Undo()
+
+	// Assert
+	if !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("got error %v, want %v", err, ErrNothingToUndo)
+	}
+}
+
+func TestUndoUnknownMoveKind(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.history = []Move{{Kind: MoveKind(7)}}
+
+	// Act
+	err := grid.Undo()
+
+	// Assert
+	if !errors.Is(err, ErrCorruptGrid) {
+		t.Errorf("got error %v, want %v", err, ErrCorruptGrid)
+	}
+}
+
+func TestRemoveShipDropsItsPlacementFromHistory(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShip(0, 0)
+	removed, _ := grid.PlaceShipAt(2, 2, 2, Horizontal)
+
+	// Act
+	grid.RemoveShip(removed)
+
+	// Assert
+	history := grid.History()
+	if len(history) != 1 || history[0].ShipID == removed {
+		t.Errorf("got history %+v, want only the first placement", history)
+	}
+}
+
+func TestRemoveShipDropsShotsThatHitIt(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	kept, _ := grid.PlaceShipAt(4, 4, 1, Horizontal)
+	removed, _ := grid.PlaceShipAt(0, 0, 2, Horizontal)
+	grid.Fire(0, 0)
+	grid.Fire(4, 4)
+
+	// Act
+	grid.RemoveShip(removed)
+
+	// Assert
+	want := []Move{
+		{Kind: PlaceMove, Row: 4, Col: 4, Length: 1, Orientation: Horizontal, ShipID: kept},
+		{Kind: FireMove, Row: 4, Col: 4, Hit: true},
+	}
+
+	if got := grid.History(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got history %+v, want %+v", got, want)
+	}
+}
+
+func TestUndoAfterRemovingHitShipLeavesNoGhost(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	id, _ := grid.PlaceShipAt(0, 0, 2, Horizontal)
+	grid.Fire(0, 0)
+	grid.RemoveShip(id)
+
+	// Act
+	err := grid.Undo()
+
+	// Assert
+	if !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("got error %v, want %v", err, ErrNothingToUndo)
+	}
+
+	if !grid.Equal(NewGrid()) {
+		t.Errorf("got grid\n%v\nwant it empty", grid)
+	}
+}
+
+func TestResetClearsHistory(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShip(0, 0)
+	grid.Fire(0, 0)
+
+	// Act
+	grid.Reset()
+
+	// Assert
+	if len(grid.History()) != 0 {
+		t.Errorf("got history %+v after reset", grid.History())
+	}
+}
+
+func TestHistorySurvivesJSONRoundTrip(t *testing.T) {
+	// Arrange
+	original := NewGrid()
+	original.PlaceShipAt(0, 0, 2, Horizontal)
+	original.Fire(0, 1)
+	data, _ := json.Marshal(original)
+
+	// Act
+	restored := NewGrid()
+	err := json.Unmarshal(data, restored)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(restored.History(), original.History()) {
+		t.Errorf("got history %+v, want %+v", restored.History(), original.History())
+	}
+}
+
+func TestUndoRestoresStreak(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(0, 0, 3, Horizontal)
+	grid.Fire(0, 0)
+	grid.Fire(0, 1)
+	grid.Fire(5, 5)
+	grid.Undo()
+
+	// Act
+	result, _ := grid.Fire(0, 2)
+
+	// Assert
+	if result.Streak != 3 {
+		t.Errorf("got streak %d, want 3 once the miss was undone", result.Streak)
+	}
+}
+
+func TestSunkShipsInOrder(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	first, _ := grid.PlaceShipAt(0, 0, 3, Horizontal)
+	second, _ := grid.PlaceShipAt(4, 4, 2, Vertical)
+	grid.Ready()
+
+	// Act
+	grid.Fire(0, 0)
+	grid.Fire(4, 4)
+	grid.Fire(6, 6)
+	grid.Fire(5, 4)
+	grid.Fire(0, 1)
+	grid.Fire(0, 2)
+
+	// Assert
+	want := []SunkInfo{{ShipID: second, Shot: 4}, {ShipID: first, Shot: 6}}
+	if got := grid.SunkShips(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestSunkShipsForgetsUndoneShots(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(0, 0, 2, Horizontal)
+	grid.Fire(0, 0)
+	grid.Fire(0, 1)
+
+	// Act
+	grid.Undo()
+
+	// Assert
+	if got := grid.SunkShips(); len(got) != 0 {
+		t.Errorf("got %+v, want no sunk ships", got)
+	}
+}
+
+func TestSunkShipsIgnoresHitsOutsideHistory(t *testing.T) {
+	// Arrange
+	grid, _ := ParseGrid("XX.\n...")
+
+	// Act
+	sunk := grid.SunkShips()
+
+	// Assert
+	if !grid.AllShipsSunk() {
+		t.Fatal("parsed grid should have all ships sunk")
+	}
+
+	if len(sunk) != 0 {
+		t.Errorf("got sunk ships %+v, want none without recorded shots", sunk)
+	}
+}
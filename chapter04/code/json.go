@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+type gridJSON struct {
+	Rows    int           `json:"rows"`
+	Cols    int           `json:"cols"`
+	Rules   Rules         `json:"rules"`
+	Cells   [][]CellState `json:"cells"`
+	Ships   []shipJSON    `json:"ships"`
+	NextID  int           `json:"nextId"`
+	History []Move        `json:"history"`
+	Ready   bool          `json:"ready"`
+}
+
+type shipJSON struct {
+	ID    int      `json:"id"`
+	Cells [][2]int `json:"cells"`
+}
+
+func (g *Grid) MarshalJSON() ([]byte, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	ids := make([]int, 0, len(g.ships))
+	for id := range g.ships {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	ships := make([]shipJSON, 0, len(ids))
+	for _, id := range ids {
+		ships = append(ships, shipJSON{ID: id, Cells: g.ships[id]})
+	}
+
+	return json.Marshal(gridJSON{
+		Rows:    g.rows,
+		Cols:    g.cols,
+		Rules:   g.rules,
+		Cells:   g.locations,
+		Ships:   ships,
+		NextID:  g.nextID,
+		History: g.history,
+		Ready:   g.ready,
+	})
+}
+
+func (g *Grid) UnmarshalJSON(data []byte) error {
+	var decoded gridJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return fmt.Errorf("%w: %v", ErrCorruptGrid, err)
+	}
+
+	ships := map[int][][2]int{}
+	for _, ship := range decoded.Ships {
+		if _, ok := ships[ship.ID]; ok {
+			return fmt.Errorf("%w: ship %d listed twice", ErrCorruptGrid, ship.ID)
+		}
+		ships[ship.ID] = ship.Cells
+	}
+
+	restored, err := restoreGrid(decoded.Rows, decoded.Cols, decoded.Rules, decoded.Cells, ships, decoded.NextID)
+	if err != nil {
+		return err
+	}
+
+	if err := restored.restoreHistory(decoded.History); err != nil {
+		return err
+	}
+	restored.ready = decoded.Ready
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.adopt(restored)
+	return nil
+}
+
+// restoreGrid rebuilds a Grid from decoded state, checking that the cells and
+// ships agree with each other and with the dimensions before trusting them.
+func restoreGrid(rows int, cols int, rules Rules, cells [][]CellState, ships map[int][][2]int, nextID int) (*Grid, error) {
+	g, err := NewGridWithRules(rows, cols, rules)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorruptGrid, err)
+	}
+
+	if nextID < 1 {
+		return nil, fmt.Errorf("%w: next ship ID %d is not positive", ErrCorruptGrid, nextID)
+	}
+
+	if len(cells) != rows {
+		return nil, fmt.Errorf("%w: got %d rows of cells, want %d", ErrCorruptGrid, len(cells), rows)
+	}
+
+	for row := range cells {
+		if len(cells[row]) != cols {
+			return nil, fmt.Errorf("%w: row %d has %d cells, want %d", ErrCorruptGrid, row, len(cells[row]), cols)
+		}
+
+		for col, state := range cells[row] {
+			if state < Empty || state > Miss {
+				return nil, fmt.Errorf("%w: unknown cell state %d at (%d, %d)", ErrCorruptGrid, state, row, col)
+			}
+			g.locations[row][col] = state
+		}
+	}
+
+	for id, shipCells := range ships {
+		if id < 1 || id >= nextID || len(shipCells) == 0 {
+			return nil, fmt.Errorf("%w: invalid ship %d", ErrCorruptGrid, id)
+		}
+
+		for _, cell := range shipCells {
+			if !g.inBounds(cell[0], cell[1]) || !g.isShipPresent(cell[0], cell[1]) || g.shipIDs[cell[0]][cell[1]] != 0 {
+				return nil, fmt.Errorf("%w: ship %d cannot occupy (%d, %d)", ErrCorruptGrid, id, cell[0], cell[1])
+			}
+			g.shipIDs[cell[0]][cell[1]] = id
+		}
+		g.ships[id] = append([][2]int(nil), shipCells...)
+	}
+
+	for row := range cells {
+		for col := range cells[row] {
+			if g.isShipPresent(row, col) && g.shipIDs[row][col] == 0 {
+				return nil, fmt.Errorf("%w: ship cell (%d, %d) belongs to no ship", ErrCorruptGrid, row, col)
+			}
+		}
+	}
+
+	g.nextID = nextID
+	return g, nil
+}
+
+// restoreHistory checks that each decoded move could have been made on the
+// restored grid, so replaying or undoing it later cannot go out of bounds.
+func (g *Grid) restoreHistory(history []Move) error {
+	for i, move := range history {
+		if !g.inBounds(move.Row, move.Col) {
+			return fmt.Errorf("%w: move %d at (%d, %d) is off the grid", ErrCorruptGrid, i, move.Row, move.Col)
+		}
+
+		switch move.Kind {
+		case PlaceMove:
+			if _, ok := g.ships[move.ShipID]; !ok {
+				return fmt.Errorf("%w: move %d places unknown ship %d", ErrCorruptGrid, i, move.ShipID)
+			}
+		case FireMove:
+			want := Miss
+			if move.Hit {
+				want = Hit
+			}
+			if g.locations[move.Row][move.Col] != want {
+				return fmt.Errorf("%w: move %d fired at (%d, %d) but the cell is %d", ErrCorruptGrid, i, move.Row, move.Col, g.locations[move.Row][move.Col])
+			}
+		default:
+			return fmt.Errorf("%w: move %d has unknown kind %d", ErrCorruptGrid, i, move.Kind)
+		}
+	}
+
+	g.history = history
+	return nil
+}
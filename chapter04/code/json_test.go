@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	// Arrange
+	original, _ := NewGridWithSize(6, 8)
+	original.PlaceShipAt(0, 0, 3, Horizontal)
+	removed, _ := original.PlaceShipAt(5, 0, 2, Horizontal)
+	original.PlaceShipAt(2, 7, 4, Vertical)
+	original.RemoveShip(removed)
+	original.Ready()
+	original.Fire(0, 1)
+	original.Fire(3, 7)
+	original.Fire(4, 4)
+
+	// Act
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("unexpected error marshalling: %v", err)
+	}
+
+	restored := NewGrid()
+	err = json.Unmarshal(data, restored)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error unmarshalling: %v", err)
+	}
+
+	if !reflect.DeepEqual(original, restored) {
+		t.Errorf("got\n%v\nwant\n%v", restored, original)
+	}
+
+	if id, _ := restored.PlaceShipAt(5, 0, 2, Horizontal); id == removed {
+		t.Errorf("restored grid reused ship ID %d", id)
+	}
+}
+
+func TestUnmarshalJSONRejectsMalformedInput(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+
+	// Act
+	err := json.Unmarshal([]byte(`{"rows": 7, "cols":`), grid)
+
+	// Assert
+	if err == nil {
+		t.Error("expected an error for truncated JSON")
+	}
+}
+
+func TestUnmarshalJSONRejectsInconsistentGrids(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"not an object", `[1, 2, 3]`},
+		{"zero size", `{"rows": 0, "cols": 2, "cells": [], "ships": [], "nextId": 1}`},
+		{"too few rows", `{"rows": 3, "cols": 2, "cells": [[0, 0], [0, 0]], "ships": [], "nextId": 1}`},
+		{"ragged row", `{"rows": 2, "cols": 2, "cells": [[0, 0], [0]], "ships": [], "nextId": 1}`},
+		{"unknown state", `{"rows": 1, "cols": 2, "cells": [[0, 9]], "ships": [], "nextId": 1}`},
+		{"ship off grid", `{"rows": 1, "cols": 2, "cells": [[1, 0]], "ships": [{"id": 1, "cells": [[0, 0], [0, 2]]}], "nextId": 2}`},
+		{"ship over water", `{"rows": 1, "cols": 2, "cells": [[1, 0]], "ships": [{"id": 1, "cells": [[0, 0], [0, 1]]}], "nextId": 2}`},
+		{"orphan ship cell", `{"rows": 1, "cols": 2, "cells": [[1, 2]], "ships": [{"id": 1, "cells": [[0, 0]]}], "nextId": 2}`},
+		{"overlapping ships", `{"rows": 1, "cols": 2, "cells": [[1, 1]], "ships": [{"id": 1, "cells": [[0, 0]]}, {"id": 2, "cells": [[0, 0], [0, 1]]}], "nextId": 3}`},
+		{"duplicate ship", `{"rows": 1, "cols": 2, "cells": [[1, 1]], "ships": [{"id": 1, "cells": [[0, 0]]}, {"id": 1, "cells": [[0, 1]]}], "nextId": 2}`},
+		{"stale next id", `{"rows": 1, "cols": 2, "cells": [[1, 0]], "ships": [{"id": 1, "cells": [[0, 0]]}], "nextId": 1}`},
+		{"zero next id", `{"rows": 3, "cols": 3, "cells": [[0, 0, 0], [0, 0, 0], [0, 0, 0]], "ships": [], "nextId": 0}`},
+		{"missing next id", `{"rows": 1, "cols": 2, "cells": [[0, 0]], "ships": []}`},
+		{"move off grid", `{"rows": 1, "cols": 2, "cells": [[2, 3]], "ships": [{"id": 1, "cells": [[0, 0]]}], "nextId": 2, "history": [{"Kind": 1, "Row": 50, "Col": 50, "Hit": true}]}`},
+		{"unknown move kind", `{"rows": 1, "cols": 2, "cells": [[0, 0]], "ships": [], "nextId": 1, "history": [{"Kind": 7, "Row": 0, "Col": 0}]}`},
+		{"placement of unknown ship", `{"rows": 1, "cols": 2, "cells": [[0, 0]], "ships": [], "nextId": 1, "history": [{"Kind": 0, "Row": 0, "Col": 0, "Length": 1, "ShipID": 4}]}`},
+		{"shot at unfired cell", `{"rows": 1, "cols": 2, "cells": [[1, 0]], "ships": [{"id": 1, "cells": [[0, 0]]}], "nextId": 2, "history": [{"Kind": 1, "Row": 0, "Col": 0, "Hit": true}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid := NewGrid()
+
+			err := json.Unmarshal([]byte(tt.data), grid)
+
+			if !errors.Is(err, ErrCorruptGrid) {
+				t.Errorf("got error %v, want %v", err, ErrCorruptGrid)
+			}
+		})
+	}
+}
+
+func TestJSONRoundTripKeepsRules(t *testing.T) {
+	// Arrange
+	original, _ := NewGridWithRules(ROWS, COLUMNS, Rules{NoTouching: true})
+	original.PlaceShip(0, 0)
+	data, _ := json.Marshal(original)
+
+	// Act
+	restored := NewGrid()
+	json.Unmarshal(data, restored)
+
+	// Assert
+	if err := restored.PlaceShip(1, 1); !errors.Is(err, ErrAdjacent) {
+		t.Errorf("got error %v, want %v", err, ErrAdjacent)
+	}
+}
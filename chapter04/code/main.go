@@ -1,24 +1,553 @@
 package main
 
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
 const (
-	ROWS = 7
+	ROWS    = 7
 	COLUMNS = 7
+)
+
+type CellState int
+
+const (
+	Empty CellState = iota
+	Ship
+	Hit
+	Miss
+)
+
+// Orientation is the direction a ship runs from its origin cell. Horizontal
+// ships run right and Vertical ships run down. DiagonalDown ships run down and
+// to the right, DiagonalUp ships up and to the right, for variants that allow
+// them.
+type Orientation int
 
-	SHIP = "SHIP"
+const (
+	Horizontal Orientation = iota
+	Vertical
+	DiagonalDown
+	DiagonalUp
 )
 
+// FireResult describes the outcome of a shot. Streak counts the hits in a row
+// ending with this shot, so it is zero after a miss. On a hit, ShipID,
+// ShipLength and ShipHits report the damage to the ship that was struck, and
+// Sunk whether this shot finished it; on a miss they are all zero.
+type FireResult struct {
+	Hit        bool
+	Sunk       bool
+	Streak     int
+	ShipID     int
+	ShipLength int
+	ShipHits   int
+}
+
+// Rules switches on optional restrictions. The zero value allows any
+// placement on the grid that does not overlap another ship, and allows firing
+// at any time. RequireReady refuses shots until Ready has been called.
+type Rules struct {
+	NoTouching   bool
+	RequireReady bool
+}
+
+// Grid is safe for concurrent use. Exported methods take the lock and do
+// their work through unexported helpers, which expect the caller to hold it.
 type Grid struct {
-	locations [ROWS][COLUMNS]string
+	mu        sync.RWMutex
+	rows      int
+	cols      int
+	rules     Rules
+	locations [][]CellState
+	shipIDs   [][]int
+	ships     map[int][][2]int
+	nextID    int
+	history   []Move
+	ready     bool
 }
 
 func NewGrid() *Grid {
-	return &Grid{}
+	grid, _ := NewGridWithSize(ROWS, COLUMNS)
+	return grid
+}
+
+func NewGridWithSize(rows int, cols int) (*Grid, error) {
+	return NewGridWithRules(rows, cols, Rules{})
+}
+
+func NewGridWithRules(rows int, cols int, rules Rules) (*Grid, error) {
+	if rows < 1 || cols < 1 {
+		return nil, fmt.Errorf("%dx%d: %w", rows, cols, ErrInvalidSize)
+	}
+
+	return &Grid{
+		rows:      rows,
+		cols:      cols,
+		rules:     rules,
+		locations: makeBoard[CellState](rows, cols),
+		shipIDs:   makeBoard[int](rows, cols),
+		ships:     map[int][][2]int{},
+		nextID:    1,
+	}, nil
+}
+
+func makeBoard[T any](rows int, cols int) [][]T {
+	board := make([][]T, rows)
+	for row := range board {
+		board[row] = make([]T, cols)
+	}
+	return board
+}
+
+func (g *Grid) Clone() *Grid {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.clone()
+}
+
+func (g *Grid) clone() *Grid {
+	clone := &Grid{
+		rows:      g.rows,
+		cols:      g.cols,
+		rules:     g.rules,
+		locations: copyBoard(g.locations),
+		shipIDs:   copyBoard(g.shipIDs),
+		ships:     make(map[int][][2]int, len(g.ships)),
+		nextID:    g.nextID,
+		history:   append([]Move(nil), g.history...),
+		ready:     g.ready,
+	}
+	for id, cells := range g.ships {
+		clone.ships[id] = append([][2]int(nil), cells...)
+	}
+	return clone
+}
+
+// adopt replaces the state of g with that of other, which must not be shared.
+func (g *Grid) adopt(other *Grid) {
+	g.rows = other.rows
+	g.cols = other.cols
+	g.rules = other.rules
+	g.locations = other.locations
+	g.shipIDs = other.shipIDs
+	g.ships = other.ships
+	g.nextID = other.nextID
+	g.history = other.history
+	g.ready = other.ready
+}
+
+// Equal reports whether two grids have the same dimensions, cell states and
+// ship layout. Placement rules and the next ship ID to be handed out are not
+// part of the position and are ignored.
+func (g *Grid) Equal(other *Grid) bool {
+	if g == nil || other == nil || g == other {
+		return g == other
+	}
+
+	other = other.Clone()
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.rows != other.rows || g.cols != other.cols {
+		return false
+	}
+
+	for row := range g.locations {
+		for col := range g.locations[row] {
+			if g.locations[row][col] != other.locations[row][col] || g.shipIDs[row][col] != other.shipIDs[row][col] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func copyBoard[T any](board [][]T) [][]T {
+	copied := make([][]T, len(board))
+	for row := range board {
+		copied[row] = append([]T(nil), board[row]...)
+	}
+	return copied
+}
+
+func (g *Grid) Dimensions() (rows int, cols int) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.rows, g.cols
+}
+
+func (g *Grid) Reset() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.locations = makeBoard[CellState](g.rows, g.cols)
+	g.shipIDs = makeBoard[int](g.rows, g.cols)
+	g.ships = map[int][][2]int{}
+	g.nextID = 1
+	g.history = nil
+	g.ready = false
+}
+
+// Ready ends placement: from now on the fleet cannot be changed, and a grid
+// with the RequireReady rule starts accepting shots.
+func (g *Grid) Ready() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.ready = true
+}
+
+func (g *Grid) IsReady() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.ready
+}
+
+func (g *Grid) PlaceShip(row int, col int) error {
+	_, err := g.PlaceShipAt(row, col, 1, Horizontal)
+	return err
+}
+
+func (g *Grid) PlaceShipAt(row int, col int, length int, orientation Orientation) (int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.placeShipAt(row, col, length, orientation)
+}
+
+func (g *Grid) placeShipAt(row int, col int, length int, orientation Orientation) (int, error) {
+	if err := g.canPlaceShip(row, col, length, orientation); err != nil {
+		return 0, err
+	}
+
+	id := g.nextID
+	g.nextID++
+
+	cells := shipLayout(row, col, length, orientation)
+	for _, cell := range cells {
+		g.locations[cell[0]][cell[1]] = Ship
+		g.shipIDs[cell[0]][cell[1]] = id
+	}
+	g.ships[id] = cells
+	g.history = append(g.history, Move{
+		Kind:        PlaceMove,
+		Row:         row,
+		Col:         col,
+		Length:      length,
+		Orientation: orientation,
+		ShipID:      id,
+	})
+	return id, nil
+}
+
+// CanPlaceShip reports whether PlaceShipAt would accept the ship, returning
+// the error it would give. The grid is not changed.
+func (g *Grid) CanPlaceShip(row int, col int, length int, orientation Orientation) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.canPlaceShip(row, col, length, orientation)
+}
+
+func (g *Grid) canPlaceShip(row int, col int, length int, orientation Orientation) error {
+	if g.ready {
+		return ErrLocked
+	}
+
+	if length < 1 {
+		return fmt.Errorf("length %d: %w", length, ErrInvalidLength)
+	}
+
+	cells := shipLayout(row, col, length, orientation)
+	for _, cell := range cells {
+		if !g.inBounds(cell[0], cell[1]) {
+			return fmt.Errorf("ship of length %d at (%d, %d) leaves the grid at (%d, %d): %w",
+				length, row, col, cell[0], cell[1], ErrOutOfBounds)
+		}
+
+		if g.isShipPresent(cell[0], cell[1]) {
+			return fmt.Errorf("ship of length %d at (%d, %d) crosses (%d, %d): %w",
+				length, row, col, cell[0], cell[1], ErrOverlap)
+		}
+
+		if g.locations[cell[0]][cell[1]] == Miss {
+			return fmt.Errorf("ship of length %d at (%d, %d) crosses a fired cell at (%d, %d): %w",
+				length, row, col, cell[0], cell[1], ErrAlreadyFired)
+		}
+	}
+
+	for _, cell := range cells {
+		if g.rules.NoTouching && g.touchesShip(cell[0], cell[1]) {
+			return fmt.Errorf("ship of length %d at (%d, %d) is next to another ship at (%d, %d): %w",
+				length, row, col, cell[0], cell[1], ErrAdjacent)
+		}
+	}
+	return nil
+}
+
+// RemoveShip takes a ship off the grid as if it had never been placed, so its
+// placement and any shots that hit it are also dropped from the history.
+func (g *Grid) RemoveShip(shipID int) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.removeShip(shipID)
+}
+
+func (g *Grid) removeShip(shipID int) error {
+	if g.ready {
+		return ErrLocked
+	}
+
+	if _, ok := g.ships[shipID]; !ok {
+		return fmt.Errorf("ship %d: %w", shipID, ErrNoSuchShip)
+	}
+
+	cells := map[[2]int]bool{}
+	for _, cell := range g.ships[shipID] {
+		cells[cell] = true
+	}
+	g.clearShip(shipID)
+
+	kept := g.history[:0]
+	for _, move := range g.history {
+		switch {
+		case move.Kind == PlaceMove && move.ShipID == shipID:
+		case move.Kind == FireMove && cells[[2]int{move.Row, move.Col}]:
+		default:
+			kept = append(kept, move)
+		}
+	}
+	g.history = kept
+	return nil
+}
+
+func (g *Grid) clearShip(shipID int) {
+	for _, cell := range g.ships[shipID] {
+		g.locations[cell[0]][cell[1]] = Empty
+		g.shipIDs[cell[0]][cell[1]] = 0
+	}
+	delete(g.ships, shipID)
+}
+
+func (g *Grid) ShipCells(shipID int) ([][2]int, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	cells, ok := g.ships[shipID]
+	if !ok {
+		return nil, fmt.Errorf("ship %d: %w", shipID, ErrNoSuchShip)
+	}
+
+	sorted := append([][2]int(nil), cells...)
+	sortCells(sorted)
+	return sorted, nil
 }
 
-func (g *Grid) PlaceShip(row int, col int) {
-	g.locations[row][col] = SHIP
+// ShipIDAt returns the ship occupying a cell, or false for water and for
+// cells off the grid.
+func (g *Grid) ShipIDAt(row int, col int) (int, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if !g.inBounds(row, col) || g.shipIDs[row][col] == 0 {
+		return 0, false
+	}
+	return g.shipIDs[row][col], true
+}
+
+func (g *Grid) IsSunk(shipID int) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.isSunk(shipID)
+}
+
+func (g *Grid) isSunk(shipID int) bool {
+	cells, ok := g.ships[shipID]
+	if !ok {
+		return false
+	}
+
+	for _, cell := range cells {
+		if g.locations[cell[0]][cell[1]] != Hit {
+			return false
+		}
+	}
+	return true
+}
+
+// AllShipsSunk reports whether the fleet has been destroyed. A grid with no
+// ships placed has no fleet to lose, so it reports false.
+func (g *Grid) AllShipsSunk() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if len(g.ships) == 0 {
+		return false
+	}
+
+	for id := range g.ships {
+		if !g.isSunk(id) {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *Grid) Fire(row int, col int) (FireResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.fire(row, col)
+}
+
+func (g *Grid) fire(row int, col int) (FireResult, error) {
+	if g.rules.RequireReady && !g.ready {
+		return FireResult{}, ErrNotReady
+	}
+
+	if !g.inBounds(row, col) {
+		return FireResult{}, fmt.Errorf("(%d, %d): %w", row, col, ErrOutOfBounds)
+	}
+
+	switch g.locations[row][col] {
+	case Hit, Miss:
+		return FireResult{}, fmt.Errorf("(%d, %d): %w", row, col, ErrAlreadyFired)
+	case Ship:
+		g.locations[row][col] = Hit
+	default:
+		g.locations[row][col] = Miss
+	}
+
+	hit := g.locations[row][col] == Hit
+	g.history = append(g.history, Move{Kind: FireMove, Row: row, Col: col, Hit: hit})
+
+	result := FireResult{Hit: hit, Streak: g.streak()}
+	if hit {
+		result.ShipID = g.shipIDs[row][col]
+		result.ShipLength = len(g.ships[result.ShipID])
+		for _, cell := range g.ships[result.ShipID] {
+			if g.locations[cell[0]][cell[1]] == Hit {
+				result.ShipHits++
+			}
+		}
+		result.Sunk = result.ShipHits == result.ShipLength
+	}
+	return result, nil
+}
+
+func (g *Grid) CellAt(row int, col int) (CellState, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.cellAt(row, col)
+}
+
+func (g *Grid) cellAt(row int, col int) (CellState, error) {
+	if !g.inBounds(row, col) {
+		return Empty, fmt.Errorf("(%d, %d): %w", row, col, ErrOutOfBounds)
+	}
+
+	return g.locations[row][col], nil
+}
+
+// ForEachCell calls fn with the state of every cell, row by row. fn sees a
+// snapshot taken before the first call, so it may read or modify the grid.
+func (g *Grid) ForEachCell(fn func(row int, col int, state CellState)) {
+	g.mu.RLock()
+	locations := copyBoard(g.locations)
+	g.mu.RUnlock()
+
+	for row := range locations {
+		for col, state := range locations[row] {
+			fn(row, col, state)
+		}
+	}
 }
 
 func (g *Grid) isShipPresent(row int, col int) bool {
-	return g.locations[row][col] == SHIP
-}
\ No newline at end of file
+	state := g.locations[row][col]
+	return state == Ship || state == Hit
+}
+
+func shipLayout(row int, col int, length int, orientation Orientation) [][2]int {
+	cells := make([][2]int, length)
+	for i := range cells {
+		switch orientation {
+		case Vertical:
+			cells[i] = [2]int{row + i, col}
+		case DiagonalDown:
+			cells[i] = [2]int{row + i, col + i}
+		case DiagonalUp:
+			cells[i] = [2]int{row - i, col + i}
+		default:
+			cells[i] = [2]int{row, col + i}
+		}
+	}
+	return cells
+}
+
+func sortCells(cells [][2]int) {
+	sort.Slice(cells, func(i, j int) bool {
+		if cells[i][0] != cells[j][0] {
+			return cells[i][0] < cells[j][0]
+		}
+		return cells[i][1] < cells[j][1]
+	})
+}
+
+func (g *Grid) touchesShip(row int, col int) bool {
+	for _, cell := range g.neighbors(row, col, true) {
+		if g.isShipPresent(cell[0], cell[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Neighbors returns the cells directly above, below, left and right of row,
+// col that lie on the grid, in row-major order.
+func (g *Grid) Neighbors(row int, col int) [][2]int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.neighbors(row, col, false)
+}
+
+// DiagonalNeighbors is like Neighbors but also includes the four diagonal
+// cells, so an interior cell has eight.
+func (g *Grid) DiagonalNeighbors(row int, col int) [][2]int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.neighbors(row, col, true)
+}
+
+func (g *Grid) neighbors(row int, col int, diagonals bool) [][2]int {
+	if !g.inBounds(row, col) {
+		return nil
+	}
+
+	var cells [][2]int
+	for r := row - 1; r <= row+1; r++ {
+		for c := col - 1; c <= col+1; c++ {
+			orthogonal := r == row || c == col
+			if (r == row && c == col) || (!orthogonal && !diagonals) || !g.inBounds(r, c) {
+				continue
+			}
+			cells = append(cells, [2]int{r, c})
+		}
+	}
+	return cells
+}
+
+func (g *Grid) inBounds(row int, col int) bool {
+	return row >= 0 && row < g.rows && col >= 0 && col < g.cols
+}
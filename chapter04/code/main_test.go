@@ -1,6 +1,10 @@
 package main
 
-import "testing"
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
 
 func TestPlacesShip(t *testing.T) {
 	// Arrange
@@ -10,9 +14,13 @@ func TestPlacesShip(t *testing.T) {
 	row := 2
 	column := 3
 
-	grid.PlaceShip(row, column)
+	err := grid.PlaceShip(row, column)
 
 	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
 	got := grid.isShipPresent(row, column)
 	want := true
 
@@ -20,3 +28,1272 @@ func TestPlacesShip(t *testing.T) {
 		t.Error("Ship was not placed")
 	}
 }
+
+func TestPlaceShipRejectsOutOfBounds(t *testing.T) {
+	tests := []struct {
+		name string
+		row  int
+		col  int
+	}{
+		{"negative row", -1, 3},
+		{"negative column", 3, -1},
+		{"row equal to ROWS", ROWS, 3},
+		{"column equal to COLUMNS", 3, COLUMNS},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			grid := NewGrid()
+
+			// Act
+			err := grid.PlaceShip(tt.row, tt.col)
+
+			// Assert
+			if !errors.Is(err, ErrOutOfBounds) {
+				t.Errorf("got error %v, want %v", err, ErrOutOfBounds)
+			}
+		})
+	}
+}
+
+func TestInBounds(t *testing.T) {
+	tests := []struct {
+		name string
+		row  int
+		col  int
+		want bool
+	}{
+		{"top left corner", 0, 0, true},
+		{"bottom right corner", ROWS - 1, COLUMNS - 1, true},
+		{"interior cell", 3, 4, true},
+		{"negative row", -1, 0, false},
+		{"negative column", 0, -1, false},
+		{"row equal to ROWS", ROWS, 0, false},
+		{"column equal to COLUMNS", 0, COLUMNS, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid := NewGrid()
+
+			got := grid.inBounds(tt.row, tt.col)
+
+			if got != tt.want {
+				t.Errorf("inBounds(%d, %d) = %v, want %v", tt.row, tt.col, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFireHitsShip(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShip(2, 3)
+
+	// Act
+	result, err := grid.Fire(2, 3)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.Hit {
+		t.Error("Shot at ship was not a hit")
+	}
+}
+
+func TestFireMissesEmptyWater(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShip(2, 3)
+
+	// Act
+	result, err := grid.Fire(4, 4)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Hit {
+		t.Error("Shot at empty water was a hit")
+	}
+}
+
+func TestFireTwiceAtSameCell(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShip(2, 3)
+	grid.Fire(2, 3)
+
+	// Act
+	_, err := grid.Fire(2, 3)
+
+	// Assert
+	if !errors.Is(err, ErrAlreadyFired) {
+		t.Errorf("got error %v, want %v", err, ErrAlreadyFired)
+	}
+}
+
+func TestFireRejectsOutOfBounds(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+
+	// Act
+	_, err := grid.Fire(ROWS, 0)
+
+	// Assert
+	if !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("got error %v, want %v", err, ErrOutOfBounds)
+	}
+}
+
+func TestFireRecordsCellState(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShip(2, 3)
+
+	// Act
+	grid.Fire(2, 3)
+	grid.Fire(4, 4)
+
+	// Assert
+	if got, _ := grid.CellAt(2, 3); got != Hit {
+		t.Errorf("got state %v at ship cell, want %v", got, Hit)
+	}
+
+	if got, _ := grid.CellAt(4, 4); got != Miss {
+		t.Errorf("got state %v at water cell, want %v", got, Miss)
+	}
+
+	if !grid.isShipPresent(2, 3) {
+		t.Error("Hit ship is no longer present")
+	}
+}
+
+func TestPlaceShipAtHorizontalOccupiesLength(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+
+	// Act
+	_, err := grid.PlaceShipAt(1, 2, 3, Horizontal)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	occupied := 0
+	for row := 0; row < ROWS; row++ {
+		for col := 0; col < COLUMNS; col++ {
+			if grid.isShipPresent(row, col) {
+				occupied++
+			}
+		}
+	}
+
+	if occupied != 3 {
+		t.Errorf("got %d occupied cells, want 3", occupied)
+	}
+
+	for col := 2; col <= 4; col++ {
+		if !grid.isShipPresent(1, col) {
+			t.Errorf("Ship missing at (1, %d)", col)
+		}
+	}
+}
+
+func TestPlaceShipAtVerticalOccupiesLength(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+
+	// Act
+	_, err := grid.PlaceShipAt(1, 2, 3, Vertical)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for row := 1; row <= 3; row++ {
+		if !grid.isShipPresent(row, 2) {
+			t.Errorf("Ship missing at (%d, 2)", row)
+		}
+	}
+}
+
+func TestPlaceShipAtRejectsShipOffRightEdge(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+
+	// Act
+	_, err := grid.PlaceShipAt(0, COLUMNS-2, 3, Horizontal)
+
+	// Assert
+	if !errors.Is(err, ErrOutOfBounds) {
+		t.Fatalf("got error %v, want %v", err, ErrOutOfBounds)
+	}
+
+	for col := COLUMNS - 2; col < COLUMNS; col++ {
+		if grid.isShipPresent(0, col) {
+			t.Errorf("Rejected ship was placed at (0, %d)", col)
+		}
+	}
+}
+
+func TestPlaceShipAtRejectsInvalidLength(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+
+	// Act
+	_, err := grid.PlaceShipAt(0, 0, 0, Horizontal)
+
+	// Assert
+	if !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("got error %v, want %v", err, ErrInvalidLength)
+	}
+}
+
+func TestPlaceShipRejectsSameCellTwice(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShip(2, 3)
+
+	// Act
+	err := grid.PlaceShip(2, 3)
+
+	// Assert
+	if !errors.Is(err, ErrOverlap) {
+		t.Errorf("got error %v, want %v", err, ErrOverlap)
+	}
+}
+
+func TestPlaceShipAtRejectsCrossingShipAtomically(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(3, 1, 4, Horizontal)
+
+	// Act
+	_, err := grid.PlaceShipAt(0, 3, 4, Vertical)
+
+	// Assert
+	if !errors.Is(err, ErrOverlap) {
+		t.Fatalf("got error %v, want %v", err, ErrOverlap)
+	}
+
+	for col := 1; col <= 4; col++ {
+		if !grid.isShipPresent(3, col) {
+			t.Errorf("Original ship missing at (3, %d)", col)
+		}
+	}
+
+	for _, row := range []int{0, 1, 2} {
+		if grid.isShipPresent(row, 3) {
+			t.Errorf("Rejected ship was partially placed at (%d, 3)", row)
+		}
+	}
+}
+
+func TestPlaceShipAtRejectsFiredCell(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.Fire(0, 1)
+
+	// Act
+	_, err := grid.PlaceShipAt(0, 0, 2, Horizontal)
+
+	// Assert
+	if !errors.Is(err, ErrAlreadyFired) {
+		t.Fatalf("got error %v, want %v", err, ErrAlreadyFired)
+	}
+
+	if state, _ := grid.CellAt(0, 1); state != Miss {
+		t.Errorf("got %v at (0, 1), want the miss to remain", state)
+	}
+
+	if grid.MissCount() != 1 || len(grid.History()) != 1 {
+		t.Errorf("got %d misses and history %+v, want the shot kept", grid.MissCount(), grid.History())
+	}
+}
+
+func TestNewGridWithSize(t *testing.T) {
+	// Arrange
+	rows := 3
+	cols := 5
+
+	// Act
+	grid, err := NewGridWithSize(rows, cols)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := grid.PlaceShip(rows-1, cols-1); err != nil {
+		t.Errorf("could not place ship in bottom right corner: %v", err)
+	}
+
+	if err := grid.PlaceShip(rows, 0); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("got error %v placing below grid, want %v", err, ErrOutOfBounds)
+	}
+
+	if err := grid.PlaceShip(0, cols); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("got error %v placing right of grid, want %v", err, ErrOutOfBounds)
+	}
+}
+
+func TestNewGridWithSizeRejectsInvalidDimensions(t *testing.T) {
+	tests := []struct {
+		name string
+		rows int
+		cols int
+	}{
+		{"zero rows", 0, 7},
+		{"zero columns", 7, 0},
+		{"negative rows", -1, 7},
+		{"negative columns", 7, -3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid, err := NewGridWithSize(tt.rows, tt.cols)
+
+			if !errors.Is(err, ErrInvalidSize) {
+				t.Errorf("got error %v, want %v", err, ErrInvalidSize)
+			}
+
+			if grid != nil {
+				t.Error("expected no grid for invalid dimensions")
+			}
+		})
+	}
+}
+
+func TestDimensions(t *testing.T) {
+	// Arrange
+	grid, _ := NewGridWithSize(10, 8)
+
+	// Act
+	rows, cols := grid.Dimensions()
+
+	// Assert
+	if rows != 10 || cols != 8 {
+		t.Errorf("got dimensions %dx%d, want 10x8", rows, cols)
+	}
+}
+
+func TestDimensionsOfDefaultGrid(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+
+	// Act
+	rows, cols := grid.Dimensions()
+
+	// Assert
+	if rows != ROWS || cols != COLUMNS {
+		t.Errorf("got dimensions %dx%d, want %dx%d", rows, cols, ROWS, COLUMNS)
+	}
+}
+
+func TestPlaceShipAtReturnsDistinctIDs(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+
+	// Act
+	first, _ := grid.PlaceShipAt(0, 0, 2, Horizontal)
+	second, _ := grid.PlaceShipAt(2, 0, 2, Horizontal)
+
+	// Assert
+	if first == second {
+		t.Errorf("both ships were given ID %d", first)
+	}
+}
+
+func TestIsSunk(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	id, _ := grid.PlaceShipAt(1, 1, 3, Horizontal)
+	grid.Fire(1, 1)
+	grid.Fire(1, 2)
+
+	// Act
+	sunkBeforeLastHit := grid.IsSunk(id)
+	grid.Fire(1, 3)
+	sunkAfterLastHit := grid.IsSunk(id)
+
+	// Assert
+	if sunkBeforeLastHit {
+		t.Error("Ship sunk with a cell still intact")
+	}
+
+	if !sunkAfterLastHit {
+		t.Error("Ship not sunk after every cell was hit")
+	}
+}
+
+func TestIsSunkOnlyConsidersItsOwnCells(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	sunk, _ := grid.PlaceShipAt(0, 0, 2, Horizontal)
+	afloat, _ := grid.PlaceShipAt(0, 2, 2, Horizontal)
+
+	// Act
+	grid.Fire(0, 0)
+	grid.Fire(0, 1)
+	grid.Fire(0, 2)
+
+	// Assert
+	if !grid.IsSunk(sunk) {
+		t.Error("Fully hit ship not sunk")
+	}
+
+	if grid.IsSunk(afloat) {
+		t.Error("Neighbouring ship sunk with a cell still intact")
+	}
+}
+
+func TestIsSunkUnknownShip(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+
+	// Act
+	got := grid.IsSunk(42)
+
+	// Assert
+	if got {
+		t.Error("Unknown ship reported as sunk")
+	}
+}
+
+func TestAllShipsSunk(t *testing.T) {
+	tests := []struct {
+		name  string
+		shots [][2]int
+		want  bool
+	}{
+		{"no shots", nil, false},
+		{"one ship partly hit", [][2]int{{0, 0}}, false},
+		{"one ship sunk, one afloat", [][2]int{{0, 0}, {0, 1}}, false},
+		{"one ship sunk, one partly hit", [][2]int{{0, 0}, {0, 1}, {3, 3}}, false},
+		{"whole fleet sunk", [][2]int{{0, 0}, {0, 1}, {3, 3}, {4, 3}, {5, 3}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			grid := NewGrid()
+			grid.PlaceShipAt(0, 0, 2, Horizontal)
+			grid.PlaceShipAt(3, 3, 3, Vertical)
+
+			// Act
+			for _, shot := range tt.shots {
+				grid.Fire(shot[0], shot[1])
+			}
+
+			// Assert
+			if got := grid.AllShipsSunk(); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllShipsSunkOnEmptyGrid(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+
+	// Act
+	got := grid.AllShipsSunk()
+
+	// Assert
+	if got {
+		t.Error("Empty grid reported all ships sunk")
+	}
+}
+
+func TestRemoveShipLeavesAdjacentShipIntact(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	removed, _ := grid.PlaceShipAt(2, 0, 3, Horizontal)
+	kept, _ := grid.PlaceShipAt(3, 0, 3, Horizontal)
+
+	// Act
+	err := grid.RemoveShip(removed)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for col := 0; col < 3; col++ {
+		if grid.isShipPresent(2, col) {
+			t.Errorf("Removed ship still present at (2, %d)", col)
+		}
+
+		if !grid.isShipPresent(3, col) {
+			t.Errorf("Adjacent ship missing at (3, %d)", col)
+		}
+	}
+
+	if _, err := grid.PlaceShipAt(2, 0, 3, Horizontal); err != nil {
+		t.Errorf("could not reuse cells of removed ship: %v", err)
+	}
+
+	if err := grid.RemoveShip(kept); err != nil {
+		t.Errorf("could not remove adjacent ship: %v", err)
+	}
+}
+
+func TestRemoveShipUnknownID(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShip(0, 0)
+
+	// Act
+	err := grid.RemoveShip(99)
+
+	// Assert
+	if !errors.Is(err, ErrNoSuchShip) {
+		t.Errorf("got error %v, want %v", err, ErrNoSuchShip)
+	}
+}
+
+func TestRemoveShipTwice(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	id, _ := grid.PlaceShipAt(0, 0, 2, Vertical)
+	grid.RemoveShip(id)
+
+	// Act
+	err := grid.RemoveShip(id)
+
+	// Assert
+	if !errors.Is(err, ErrNoSuchShip) {
+		t.Errorf("got error %v, want %v", err, ErrNoSuchShip)
+	}
+}
+
+func TestResetClearsShipsAndShots(t *testing.T) {
+	// Arrange
+	grid, _ := NewGridWithSize(5, 6)
+	grid.PlaceShipAt(0, 0, 3, Horizontal)
+	grid.Fire(0, 0)
+	grid.Fire(4, 5)
+
+	// Act
+	grid.Reset()
+
+	// Assert
+	rows, cols := grid.Dimensions()
+	if rows != 5 || cols != 6 {
+		t.Errorf("got dimensions %dx%d after reset, want 5x6", rows, cols)
+	}
+
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			if state, _ := grid.CellAt(row, col); state != Empty {
+				t.Errorf("got state %v at (%d, %d), want %v", state, row, col, Empty)
+			}
+		}
+	}
+
+	if grid.AllShipsSunk() {
+		t.Error("Reset grid reported all ships sunk")
+	}
+
+	if result, err := grid.Fire(0, 0); result.Hit || err != nil {
+		t.Errorf("got (%+v, %v) firing at reset grid, want a miss", result, err)
+	}
+}
+
+func TestCellAt(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(0, 0, 2, Horizontal)
+	grid.Fire(0, 1)
+	grid.Fire(5, 5)
+
+	tests := []struct {
+		name string
+		row  int
+		col  int
+		want CellState
+	}{
+		{"empty water", 3, 3, Empty},
+		{"intact ship", 0, 0, Ship},
+		{"hit ship", 0, 1, Hit},
+		{"missed shot", 5, 5, Miss},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, err := grid.CellAt(tt.row, tt.col)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("got state %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCellAtRejectsOutOfBounds(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+
+	// Act
+	_, err := grid.CellAt(-1, COLUMNS)
+
+	// Assert
+	if !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("got error %v, want %v", err, ErrOutOfBounds)
+	}
+}
+
+func TestNoTouchingRuleRejectsDiagonalNeighbour(t *testing.T) {
+	// Arrange
+	grid, _ := NewGridWithRules(ROWS, COLUMNS, Rules{NoTouching: true})
+	grid.PlaceShipAt(1, 1, 2, Horizontal)
+
+	// Act
+	_, err := grid.PlaceShipAt(2, 3, 2, Vertical)
+
+	// Assert
+	if !errors.Is(err, ErrAdjacent) {
+		t.Fatalf("got error %v, want %v", err, ErrAdjacent)
+	}
+
+	if grid.isShipPresent(2, 3) || grid.isShipPresent(3, 3) {
+		t.Error("Rejected ship was placed")
+	}
+}
+
+func TestNoTouchingRuleAllowsGap(t *testing.T) {
+	// Arrange
+	grid, _ := NewGridWithRules(ROWS, COLUMNS, Rules{NoTouching: true})
+	grid.PlaceShipAt(1, 1, 2, Horizontal)
+
+	// Act
+	_, err := grid.PlaceShipAt(3, 1, 2, Horizontal)
+
+	// Assert
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestDefaultRulesAllowDiagonalNeighbour(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(1, 1, 2, Horizontal)
+
+	// Act
+	_, err := grid.PlaceShipAt(2, 3, 2, Vertical)
+
+	// Assert
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestShipCells(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShip(0, 0)
+	id, _ := grid.PlaceShipAt(2, 4, 3, Vertical)
+
+	// Act
+	got, err := grid.ShipCells(id)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := [][2]int{{2, 4}, {3, 4}, {4, 4}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestShipCellsUnknownShip(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+
+	// Act
+	_, err := grid.ShipCells(1)
+
+	// Assert
+	if !errors.Is(err, ErrNoSuchShip) {
+		t.Errorf("got error %v, want %v", err, ErrNoSuchShip)
+	}
+}
+
+func TestCloneIsIndependent(t *testing.T) {
+	// Arrange
+	original := NewGrid()
+	id, _ := original.PlaceShipAt(0, 0, 2, Horizontal)
+	before := original.String()
+
+	// Act
+	clone := original.Clone()
+	clone.Fire(0, 0)
+	clone.Fire(0, 1)
+	clone.Fire(4, 4)
+	clone.PlaceShip(6, 6)
+	clone.RemoveShip(id)
+
+	// Assert
+	if got := original.String(); got != before {
+		t.Errorf("original changed after mutating clone:\n%s", got)
+	}
+
+	if !reflect.DeepEqual(original.ships[id], [][2]int{{0, 0}, {0, 1}}) {
+		t.Errorf("original lost ship %d", id)
+	}
+
+	if original.IsSunk(id) {
+		t.Error("Sinking the clone's ship sank the original's")
+	}
+}
+
+func TestCloneCopiesState(t *testing.T) {
+	// Arrange
+	original, _ := NewGridWithRules(4, 5, Rules{NoTouching: true})
+	original.PlaceShipAt(1, 1, 3, Horizontal)
+	original.Fire(1, 2)
+
+	// Act
+	clone := original.Clone()
+
+	// Assert
+	if !reflect.DeepEqual(original, clone) {
+		t.Errorf("got\n%v\nwant\n%v", clone, original)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	// Arrange
+	grid, _ := NewGridWithSize(5, 5)
+	grid.PlaceShipAt(0, 0, 3, Horizontal)
+	grid.PlaceShipAt(2, 2, 2, Vertical)
+	grid.Fire(0, 1)
+	clone := grid.Clone()
+
+	// Act
+	equalBeforeShot := grid.Equal(clone)
+	clone.Fire(4, 4)
+	equalAfterShot := grid.Equal(clone)
+
+	// Assert
+	if !equalBeforeShot {
+		t.Error("Grid not equal to its clone")
+	}
+
+	if equalAfterShot {
+		t.Error("Grid equal to its clone after firing on the clone")
+	}
+}
+
+func TestEqualComparesLayout(t *testing.T) {
+	tests := []struct {
+		name  string
+		other func() *Grid
+	}{
+		{"different size", func() *Grid {
+			other, _ := NewGridWithSize(5, 6)
+			other.PlaceShipAt(0, 0, 3, Horizontal)
+			return other
+		}},
+		{"different ship position", func() *Grid {
+			other, _ := NewGridWithSize(5, 5)
+			other.PlaceShipAt(1, 0, 3, Horizontal)
+			return other
+		}},
+		{"same cells split into two ships", func() *Grid {
+			other, _ := NewGridWithSize(5, 5)
+			other.PlaceShipAt(0, 0, 2, Horizontal)
+			other.PlaceShip(0, 2)
+			return other
+		}},
+		{"nil", func() *Grid {
+			return nil
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid, _ := NewGridWithSize(5, 5)
+			grid.PlaceShipAt(0, 0, 3, Horizontal)
+
+			if grid.Equal(tt.other()) {
+				t.Error("Grids with different layouts reported equal")
+			}
+		})
+	}
+}
+
+func TestEqualIgnoresHowLayoutWasReached(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(0, 0, 3, Horizontal)
+
+	other, _ := NewGridWithRules(ROWS, COLUMNS, Rules{NoTouching: true})
+	other.PlaceShipAt(0, 0, 3, Horizontal)
+
+	// Act
+	got := grid.Equal(other)
+
+	// Assert
+	if !got {
+		t.Error("Grids with the same layout reported unequal")
+	}
+}
+
+func TestEqualNil(t *testing.T) {
+	var grid *Grid
+
+	if !grid.Equal(nil) {
+		t.Error("nil grid not equal to nil")
+	}
+
+	if grid.Equal(NewGrid()) {
+		t.Error("nil grid equal to an empty grid")
+	}
+}
+
+func TestCanPlaceShip(t *testing.T) {
+	// Arrange
+	grid, _ := NewGridWithRules(ROWS, COLUMNS, Rules{NoTouching: true})
+	grid.PlaceShipAt(3, 1, 4, Horizontal)
+	before := grid.Clone()
+
+	tests := []struct {
+		name   string
+		row    int
+		col    int
+		length int
+		want   error
+	}{
+		{"legal", 0, 0, 2, nil},
+		{"blocked", 1, 2, 3, ErrOverlap},
+		{"touching", 0, 5, 3, ErrAdjacent},
+		{"off the grid", 5, 6, 3, ErrOutOfBounds},
+		{"zero length", 0, 0, 0, ErrInvalidLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := grid.CanPlaceShip(tt.row, tt.col, tt.length, Vertical)
+
+			// Assert
+			if !errors.Is(err, tt.want) {
+				t.Errorf("got error %v, want %v", err, tt.want)
+			}
+
+			if !grid.Equal(before) || grid.nextID != before.nextID {
+				t.Errorf("grid changed by checking a placement:\n%v", grid)
+			}
+		})
+	}
+}
+
+func TestForEachCell(t *testing.T) {
+	// Arrange
+	grid, _ := NewGridWithSize(3, 4)
+	grid.PlaceShipAt(1, 2, 2, Vertical)
+	grid.Fire(2, 2)
+	grid.Fire(0, 0)
+
+	var visited [][2]int
+	states := map[[2]int]CellState{}
+
+	// Act
+	grid.ForEachCell(func(row int, col int, state CellState) {
+		visited = append(visited, [2]int{row, col})
+		states[[2]int{row, col}] = state
+	})
+
+	// Assert
+	if len(visited) != 12 {
+		t.Fatalf("visited %d cells, want 12", len(visited))
+	}
+
+	for i, cell := range visited {
+		if want := [2]int{i / 4, i % 4}; cell != want {
+			t.Errorf("visit %d was %v, want %v", i, cell, want)
+		}
+	}
+
+	want := map[[2]int]CellState{{1, 2}: Ship, {2, 2}: Hit, {0, 0}: Miss, {0, 1}: Empty}
+	for cell, state := range want {
+		if states[cell] != state {
+			t.Errorf("got state %v at %v, want %v", states[cell], cell, state)
+		}
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	tests := []struct {
+		name string
+		row  int
+		col  int
+		want [][2]int
+	}{
+		{"top left corner", 0, 0, [][2]int{{0, 1}, {1, 0}}},
+		{"bottom right corner", 4, 5, [][2]int{{3, 5}, {4, 4}}},
+		{"top edge", 0, 2, [][2]int{{0, 1}, {0, 3}, {1, 2}}},
+		{"left edge", 2, 0, [][2]int{{1, 0}, {2, 1}, {3, 0}}},
+		{"interior", 2, 3, [][2]int{{1, 3}, {2, 2}, {2, 4}, {3, 3}}},
+		{"off the grid", 5, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid, _ := NewGridWithSize(5, 6)
+
+			got := grid.Neighbors(tt.row, tt.col)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiagonalNeighbors(t *testing.T) {
+	tests := []struct {
+		name string
+		row  int
+		col  int
+		want int
+	}{
+		{"corner", 0, 0, 3},
+		{"edge", 0, 2, 5},
+		{"interior", 2, 3, 8},
+		{"off the grid", -1, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid, _ := NewGridWithSize(5, 6)
+
+			got := grid.DiagonalNeighbors(tt.row, tt.col)
+
+			if len(got) != tt.want {
+				t.Errorf("got %d neighbours %v, want %d", len(got), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaceShipAtDiagonal(t *testing.T) {
+	tests := []struct {
+		name        string
+		row         int
+		col         int
+		orientation Orientation
+		want        [][2]int
+	}{
+		{"diagonal down", 1, 2, DiagonalDown, [][2]int{{1, 2}, {2, 3}, {3, 4}}},
+		{"diagonal up", 4, 0, DiagonalUp, [][2]int{{2, 2}, {3, 1}, {4, 0}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			grid := NewGrid()
+
+			// Act
+			id, err := grid.PlaceShipAt(tt.row, tt.col, 3, tt.orientation)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got, _ := grid.ShipCells(id); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got cells %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaceShipAtDiagonalRejectsShipOffCorner(t *testing.T) {
+	tests := []struct {
+		name        string
+		row         int
+		col         int
+		orientation Orientation
+	}{
+		{"down past bottom right", ROWS - 2, COLUMNS - 2, DiagonalDown},
+		{"up past top right", 1, COLUMNS - 3, DiagonalUp},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			grid := NewGrid()
+
+			// Act
+			_, err := grid.PlaceShipAt(tt.row, tt.col, 3, tt.orientation)
+
+			// Assert
+			if !errors.Is(err, ErrOutOfBounds) {
+				t.Errorf("got error %v, want %v", err, ErrOutOfBounds)
+			}
+
+			if !grid.Equal(NewGrid()) {
+				t.Errorf("rejected ship was placed:\n%v", grid)
+			}
+		})
+	}
+}
+
+func TestPlaceShipAtDiagonalRejectsOverlap(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(0, 2, 3, Vertical)
+
+	// Act
+	_, err := grid.PlaceShipAt(0, 0, 3, DiagonalDown)
+
+	// Assert
+	if !errors.Is(err, ErrOverlap) {
+		t.Errorf("got error %v, want %v", err, ErrOverlap)
+	}
+}
+
+func TestFireReportsStreak(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(0, 0, 5, Horizontal)
+	shots := [][2]int{{0, 0}, {0, 1}, {3, 3}, {0, 2}}
+	want := []int{1, 2, 0, 1}
+
+	for i, shot := range shots {
+		// Act
+		result, err := grid.Fire(shot[0], shot[1])
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unexpected error on shot %d: %v", i, err)
+		}
+
+		if result.Streak != want[i] {
+			t.Errorf("got streak %d after shot %d, want %d", result.Streak, i, want[i])
+		}
+	}
+}
+
+func TestFireStreakIgnoresRejectedShots(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(0, 0, 3, Horizontal)
+	grid.Fire(0, 0)
+	grid.Fire(0, 0)
+	grid.Fire(-1, 0)
+
+	// Act
+	result, _ := grid.Fire(0, 1)
+
+	// Assert
+	if result.Streak != 2 {
+		t.Errorf("got streak %d, want 2", result.Streak)
+	}
+}
+
+func TestFireReportsSinkingShot(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(0, 0, 2, Horizontal)
+
+	// Act
+	first, _ := grid.Fire(0, 0)
+	second, _ := grid.Fire(0, 1)
+
+	// Assert
+	if first.Sunk {
+		t.Error("First hit reported the ship sunk")
+	}
+
+	if !second.Sunk {
+		t.Error("Final hit did not report the ship sunk")
+	}
+}
+
+func TestRequireReadyRefusesShotsUntilReady(t *testing.T) {
+	// Arrange
+	grid, _ := NewGridWithRules(ROWS, COLUMNS, Rules{RequireReady: true})
+	grid.PlaceShip(0, 0)
+
+	// Act
+	_, errBefore := grid.Fire(0, 0)
+	grid.Ready()
+	result, errAfter := grid.Fire(0, 0)
+
+	// Assert
+	if !errors.Is(errBefore, ErrNotReady) {
+		t.Errorf("got error %v firing before ready, want %v", errBefore, ErrNotReady)
+	}
+
+	if errAfter != nil || !result.Hit {
+		t.Errorf("got (%+v, %v) firing after ready, want a hit", result, errAfter)
+	}
+}
+
+func TestRequireReadyRefusesSalvosUntilReady(t *testing.T) {
+	// Arrange
+	grid, _ := NewGridWithRules(ROWS, COLUMNS, Rules{RequireReady: true})
+	grid.PlaceShip(0, 0)
+	salvo := [][2]int{{0, 0}, {1, 1}}
+
+	// Act
+	_, errBefore := grid.FireSalvo(salvo)
+	state, _ := grid.CellAt(0, 0)
+	grid.Ready()
+	hits, errAfter := grid.FireSalvo(salvo)
+
+	// Assert
+	if !errors.Is(errBefore, ErrNotReady) {
+		t.Errorf("got error %v firing before ready, want %v", errBefore, ErrNotReady)
+	}
+
+	if state != Ship {
+		t.Errorf("got %v at (0, 0) after the refused salvo, want %v", state, Ship)
+	}
+
+	if errAfter != nil || !reflect.DeepEqual(hits, []bool{true, false}) {
+		t.Errorf("got (%v, %v) firing after ready, want [true false]", hits, errAfter)
+	}
+}
+
+func TestReadyLocksFleet(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	id, _ := grid.PlaceShipAt(0, 0, 2, Horizontal)
+
+	// Act
+	grid.Ready()
+
+	// Assert
+	if !grid.IsReady() {
+		t.Error("Grid not ready after Ready")
+	}
+
+	if err := grid.PlaceShip(4, 4); !errors.Is(err, ErrLocked) {
+		t.Errorf("got error %v placing after ready, want %v", err, ErrLocked)
+	}
+
+	if err := grid.CanPlaceShip(4, 4, 1, Horizontal); !errors.Is(err, ErrLocked) {
+		t.Errorf("got error %v checking a placement after ready, want %v", err, ErrLocked)
+	}
+
+	if err := grid.RemoveShip(id); !errors.Is(err, ErrLocked) {
+		t.Errorf("got error %v removing after ready, want %v", err, ErrLocked)
+	}
+
+	if err := grid.Undo(); !errors.Is(err, ErrLocked) {
+		t.Errorf("got error %v undoing a placement after ready, want %v", err, ErrLocked)
+	}
+
+	if _, err := grid.Fire(0, 0); err != nil {
+		t.Errorf("unexpected error firing after ready: %v", err)
+	}
+}
+
+func TestResetClearsReady(t *testing.T) {
+	// Arrange
+	grid, _ := NewGridWithRules(ROWS, COLUMNS, Rules{RequireReady: true})
+	grid.Ready()
+
+	// Act
+	grid.Reset()
+
+	// Assert
+	if grid.IsReady() {
+		t.Error("Grid still ready after reset")
+	}
+
+	if err := grid.PlaceShip(0, 0); err != nil {
+		t.Errorf("unexpected error placing after reset: %v", err)
+	}
+}
+
+func TestShipIDAt(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(0, 0, 2, Horizontal)
+	id, _ := grid.PlaceShipAt(2, 3, 3, Vertical)
+	grid.Fire(3, 3)
+
+	tests := []struct {
+		name   string
+		row    int
+		col    int
+		wantID int
+		wantOK bool
+	}{
+		{"intact ship cell", 2, 3, id, true},
+		{"hit ship cell", 3, 3, id, true},
+		{"water", 5, 5, 0, false},
+		{"off the grid", ROWS, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			gotID, gotOK := grid.ShipIDAt(tt.row, tt.col)
+
+			// Assert
+			if gotID != tt.wantID || gotOK != tt.wantOK {
+				t.Errorf("got (%d, %v), want (%d, %v)", gotID, gotOK, tt.wantID, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFireReportsDamageToShip(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShip(6, 6)
+	cruiser, _ := grid.PlaceShipAt(1, 1, 3, Vertical)
+	grid.Fire(1, 1)
+
+	// Act
+	damaging, _ := grid.Fire(2, 1)
+	sinking, _ := grid.Fire(3, 1)
+
+	// Assert
+	wantDamaging := FireResult{Hit: true, Streak: 2, ShipID: cruiser, ShipLength: 3, ShipHits: 2}
+	if damaging != wantDamaging {
+		t.Errorf("got %+v, want %+v", damaging, wantDamaging)
+	}
+
+	wantSinking := FireResult{Hit: true, Sunk: true, Streak: 3, ShipID: cruiser, ShipLength: 3, ShipHits: 3}
+	if sinking != wantSinking {
+		t.Errorf("got %+v, want %+v", sinking, wantSinking)
+	}
+}
+
+func TestFireReportsNoShipOnMiss(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(1, 1, 3, Vertical)
+
+	// Act
+	result, _ := grid.Fire(5, 5)
+
+	// Assert
+	if result != (FireResult{}) {
+		t.Errorf("got %+v, want an empty result for a miss", result)
+	}
+}
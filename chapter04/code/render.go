@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var cellSymbols = map[CellState]byte{
+	Empty: '.',
+	Ship:  'S',
+	Hit:   'X',
+	Miss:  'o',
+}
+
+// String renders the owner's view of the board, which is the same as
+// RevealString.
+func (g *Grid) String() string {
+	return g.RevealString()
+}
+
+// RevealString renders the whole board, including ships that have never been
+// fired at, for showing where the fleet was at the end of a game.
+func (g *Grid) RevealString() string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.render(func(row int, col int) byte {
+		return cellSymbols[g.locations[row][col]]
+	})
+}
+
+// FogString renders the opponent's view of the board: hits and misses are
+// shown, but ships that have not been hit look like open water.
+func (g *Grid) FogString() string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.render(func(row int, col int) byte {
+		if g.locations[row][col] == Ship {
+			return cellSymbols[Empty]
+		}
+		return cellSymbols[g.locations[row][col]]
+	})
+}
+
+// PreviewString renders the board with a ship that has not been placed yet
+// drawn as '?', for showing where a dragged ship would land. It returns the
+// error PlaceShipAt would give if the placement is not allowed. The grid is
+// not changed.
+func (g *Grid) PreviewString(row int, col int, length int, orientation Orientation) (string, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if err := g.canPlaceShip(row, col, length, orientation); err != nil {
+		return "", err
+	}
+
+	ghost := map[[2]int]bool{}
+	for _, cell := range shipLayout(row, col, length, orientation) {
+		ghost[cell] = true
+	}
+
+	return g.render(func(r int, c int) byte {
+		if ghost[[2]int{r, c}] {
+			return '?'
+		}
+		return cellSymbols[g.locations[r][c]]
+	}), nil
+}
+
+// render lays out the board with column and row labels, asking symbol for
+// the character to show in each cell.
+func (g *Grid) render(symbol func(row int, col int) byte) string {
+	labelWidth := len(fmt.Sprint(g.rows))
+	cellWidth := len(columnLabel(g.cols - 1))
+
+	var sb strings.Builder
+	sb.WriteString(strings.Repeat(" ", labelWidth))
+	for col := 0; col < g.cols; col++ {
+		fmt.Fprintf(&sb, " %*s", cellWidth, columnLabel(col))
+	}
+	sb.WriteByte('\n')
+
+	for row := 0; row < g.rows; row++ {
+		fmt.Fprintf(&sb, "%*d", labelWidth, row+1)
+		for col := 0; col < g.cols; col++ {
+			fmt.Fprintf(&sb, " %*c", cellWidth, symbol(row, col))
+		}
+		sb.WriteByte('\n')
+	}
+
+	return sb.String()
+}
+
+// columnLabel names columns A to Z, then AA, AB and so on, like a spreadsheet.
+func columnLabel(col int) string {
+	label := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		label = string(rune('A'+(col-1)%26)) + label
+	}
+	return label
+}
+
+// ParseGrid reads a board in the format written by String. The column header
+// and row numbers are optional, and cells may be written without spaces, so
+// fixtures can be as short as "S.\n.o". Each orthogonally connected group of
+// ship cells becomes one ship, numbered in reading order; ships that touch in
+// the text therefore come back as a single ship.
+func ParseGrid(layout string) (*Grid, error) {
+	var cells [][]CellState
+	for _, line := range strings.Split(layout, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || (len(cells) == 0 && fields[0] == "A") {
+			continue
+		}
+
+		if _, err := strconv.Atoi(fields[0]); err == nil {
+			fields = fields[1:]
+		}
+
+		if len(fields) == 1 {
+			fields = strings.Split(fields[0], "")
+		}
+
+		row := make([]CellState, len(fields))
+		for col, field := range fields {
+			state, ok := parseCellSymbol(field)
+			if !ok {
+				return nil, fmt.Errorf("%w: unknown cell %q in row %d", ErrInvalidLayout, field, len(cells)+1)
+			}
+			row[col] = state
+		}
+
+		if len(cells) > 0 && len(row) != len(cells[0]) {
+			return nil, fmt.Errorf("%w: row %d has %d cells, want %d", ErrInvalidLayout, len(cells)+1, len(row), len(cells[0]))
+		}
+		cells = append(cells, row)
+	}
+
+	if len(cells) == 0 || len(cells[0]) == 0 {
+		return nil, fmt.Errorf("%w: no cells", ErrInvalidLayout)
+	}
+
+	g, err := NewGridWithSize(len(cells), len(cells[0]))
+	if err != nil {
+		return nil, err
+	}
+	g.locations = cells
+
+	for row := range cells {
+		for col := range cells[row] {
+			if g.isShipPresent(row, col) && g.shipIDs[row][col] == 0 {
+				g.claimShip(row, col, g.nextID)
+				g.nextID++
+			}
+		}
+	}
+	return g, nil
+}
+
+func parseCellSymbol(field string) (CellState, bool) {
+	for state, symbol := range cellSymbols {
+		if field == string(symbol) {
+			return state, true
+		}
+	}
+	return Empty, false
+}
+
+// claimShip assigns id to the ship cell at row, col and every ship cell
+// orthogonally connected to it.
+func (g *Grid) claimShip(row int, col int, id int) {
+	pending := [][2]int{{row, col}}
+	g.shipIDs[row][col] = id
+
+	for len(pending) > 0 {
+		cell := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		g.ships[id] = append(g.ships[id], cell)
+
+		for _, next := range g.neighbors(cell[0], cell[1], false) {
+			if g.isShipPresent(next[0], next[1]) && g.shipIDs[next[0]][next[1]] == 0 {
+				g.shipIDs[next[0]][next[1]] = id
+				pending = append(pending, next)
+			}
+		}
+	}
+	sortCells(g.ships[id])
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStringRendersGrid(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(1, 1, 3, Horizontal)
+	grid.Fire(1, 2)
+	grid.Fire(4, 5)
+
+	// Act
+	got := grid.String()
+
+	// Assert
+	want := "" +
+		"  A B C D E F G\n" +
+		"1 . . . . . . .\n" +
+		"2 . S X S . . .\n" +
+		"3 . . . . . . .\n" +
+		"4 . . . . . . .\n" +
+		"5 . . . . . o .\n" +
+		"6 . . . . . . .\n" +
+		"7 . . . . . . .\n"
+
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGridImplementsStringer(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShip(0, 0)
+
+	// Act
+	got := fmt.Sprint(grid)
+
+	// Assert
+	if !strings.Contains(got, "1 S . . . . . .\n") {
+		t.Errorf("rendered grid missing first row:\n%s", got)
+	}
+}
+
+func TestStringAlignsLargeGrid(t *testing.T) {
+	// Arrange
+	grid, _ := NewGridWithSize(10, 28)
+	grid.PlaceShip(9, 27)
+
+	// Act
+	lines := strings.Split(strings.TrimSuffix(grid.String(), "\n"), "\n")
+
+	// Assert
+	if len(lines) != 11 {
+		t.Fatalf("got %d lines, want 11", len(lines))
+	}
+
+	for i, line := range lines {
+		if len(line) != len(lines[0]) {
+			t.Errorf("line %d has width %d, want %d", i, len(line), len(lines[0]))
+		}
+	}
+
+	if !strings.HasSuffix(lines[0], " Z AA AB") {
+		t.Errorf("got header %q, want it to end with spreadsheet style labels", lines[0])
+	}
+
+	if !strings.HasPrefix(lines[10], "10  .") || !strings.HasSuffix(lines[10], "  S") {
+		t.Errorf("got last row %q", lines[10])
+	}
+}
+
+func TestParseGridRoundTripsString(t *testing.T) {
+	// Arrange
+	original, _ := NewGridWithSize(6, 9)
+	original.PlaceShipAt(0, 1, 3, Horizontal)
+	original.PlaceShipAt(2, 0, 4, Vertical)
+	original.PlaceShipAt(2, 8, 2, Vertical)
+	original.Fire(0, 2)
+	original.Fire(3, 0)
+	original.Fire(2, 8)
+	original.Fire(3, 8)
+	original.Fire(5, 5)
+
+	// Act
+	parsed, err := ParseGrid(original.String())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !parsed.Equal(original) {
+		t.Errorf("got\n%v\nwant\n%v", parsed, original)
+	}
+}
+
+func TestParseGridCompactLayout(t *testing.T) {
+	// Arrange
+	layout := `
+		SSX.
+		....
+		o..S
+	`
+
+	// Act
+	grid, err := ParseGrid(layout)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rows, cols := grid.Dimensions(); rows != 3 || cols != 4 {
+		t.Errorf("got dimensions %dx%d, want 3x4", rows, cols)
+	}
+
+	if got, _ := grid.CellAt(2, 0); got != Miss {
+		t.Errorf("got state %v at (2, 0), want %v", got, Miss)
+	}
+
+	if got, _ := grid.ShipCells(1); !reflect.DeepEqual(got, [][2]int{{0, 0}, {0, 1}, {0, 2}}) {
+		t.Errorf("got first ship cells %v", got)
+	}
+
+	if got, _ := grid.ShipCells(2); !reflect.DeepEqual(got, [][2]int{{2, 3}}) {
+		t.Errorf("got second ship cells %v", got)
+	}
+}
+
+func TestParseGridRejectsBadLayouts(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout string
+	}{
+		{"empty", ""},
+		{"ragged rows", "...\n..\n..."},
+		{"unknown character", "..S\n.#."},
+		{"header only", "  A B C\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseGrid(tt.layout)
+
+			if !errors.Is(err, ErrInvalidLayout) {
+				t.Errorf("got error %v, want %v", err, ErrInvalidLayout)
+			}
+		})
+	}
+}
+
+func TestPreviewStringShowsGhostShip(t *testing.T) {
+	// Arrange
+	grid, _ := NewGridWithSize(4, 5)
+	grid.PlaceShipAt(0, 0, 2, Horizontal)
+	before := grid.Clone()
+
+	// Act
+	got, err := grid.PreviewString(1, 2, 3, Vertical)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "" +
+		"  A B C D E\n" +
+		"1 S S . . .\n" +
+		"2 . . ? . .\n" +
+		"3 . . ? . .\n" +
+		"4 . . ? . .\n"
+
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	if !grid.Equal(before) || grid.TotalShips() != 1 {
+		t.Errorf("previewing changed the grid:\n%v", grid)
+	}
+}
+
+func TestPreviewStringRejectsIllegalPlacement(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(0, 0, 2, Horizontal)
+
+	// Act
+	got, err := grid.PreviewString(0, 1, 3, Vertical)
+
+	// Assert
+	if !errors.Is(err, ErrOverlap) {
+		t.Errorf("got error %v, want %v", err, ErrOverlap)
+	}
+
+	if got != "" {
+		t.Errorf("got preview\n%s\nfor an illegal placement", got)
+	}
+}
+
+func TestRevealStringShowsUntouchedShips(t *testing.T) {
+	// Arrange
+	grid, _ := NewGridWithSize(2, 4)
+	grid.PlaceShipAt(0, 0, 2, Horizontal)
+	grid.PlaceShipAt(1, 2, 2, Horizontal)
+	grid.Fire(0, 0)
+	grid.Fire(0, 1)
+	grid.Fire(1, 0)
+
+	// Act
+	got := grid.RevealString()
+
+	// Assert
+	want := "" +
+		"  A B C D\n" +
+		"1 X X . .\n" +
+		"2 o . S S\n"
+
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	if grid.String() != got {
+		t.Errorf("owner's view differs from the reveal:\n%s", grid.String())
+	}
+}
+
+func TestFogStringHidesUntouchedShips(t *testing.T) {
+	// Arrange
+	grid, _ := NewGridWithSize(2, 4)
+	grid.PlaceShipAt(0, 0, 2, Horizontal)
+	grid.PlaceShipAt(1, 2, 2, Horizontal)
+	grid.Fire(0, 0)
+	grid.Fire(1, 0)
+
+	// Act
+	got := grid.FogString()
+
+	// Assert
+	want := "" +
+		"  A B C D\n" +
+		"1 X . . .\n" +
+		"2 o . . .\n"
+
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	water, _ := NewGridWithSize(2, 4)
+	water.PlaceShip(0, 0)
+	water.Fire(0, 0)
+	water.Fire(1, 0)
+	if water.FogString() != got {
+		t.Errorf("intact ships can be told apart from water:\n%s\n%s", got, water.FogString())
+	}
+
+	if !strings.Contains(grid.RevealString(), "2 o . S S") {
+		t.Errorf("reveal lost the hidden ship:\n%s", grid.RevealString())
+	}
+}
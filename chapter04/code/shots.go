@@ -0,0 +1,109 @@
+package main
+
+import "fmt"
+
+// FireSalvo fires every shot in coords, in order, and returns whether each
+// one hit. A salvo is all or nothing: if any shot is off the grid, already
+// fired at, or repeated within the salvo, no shots are fired and the error
+// gives the index of the first bad one. Rules that require the grid to be
+// ready refuse the whole salvo with ErrNotReady.
+func (g *Grid) FireSalvo(coords [][2]int) ([]bool, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.rules.RequireReady && !g.ready {
+		return nil, ErrNotReady
+	}
+
+	seen := map[[2]int]bool{}
+	for i, cell := range coords {
+		state, err := g.cellAt(cell[0], cell[1])
+		if err == nil && (state == Hit || state == Miss || seen[cell]) {
+			err = fmt.Errorf("(%d, %d): %w", cell[0], cell[1], ErrAlreadyFired)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("salvo shot %d: %w", i, err)
+		}
+		seen[cell] = true
+	}
+
+	hits := make([]bool, len(coords))
+	for i, cell := range coords {
+		result, err := g.fire(cell[0], cell[1])
+		if err != nil {
+			return nil, fmt.Errorf("salvo shot %d: %w", i, err)
+		}
+		hits[i] = result.Hit
+	}
+	return hits, nil
+}
+
+func (g *Grid) HitCount() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.countState(Hit)
+}
+
+func (g *Grid) MissCount() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.countState(Miss)
+}
+
+func (g *Grid) ShotCount() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.countState(Hit) + g.countState(Miss)
+}
+
+func (g *Grid) Hits() [][2]int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.cellsInState(Hit)
+}
+
+func (g *Grid) Misses() [][2]int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.cellsInState(Miss)
+}
+
+// AvailableTargets lists every cell that has not been fired at yet, whether or
+// not it hides a ship.
+func (g *Grid) AvailableTargets() [][2]int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.cellsInState(Empty, Ship)
+}
+
+func (g *Grid) cellsInState(states ...CellState) [][2]int {
+	var cells [][2]int
+	for row := range g.locations {
+		for col, cell := range g.locations[row] {
+			for _, state := range states {
+				if cell == state {
+					cells = append(cells, [2]int{row, col})
+				}
+			}
+		}
+	}
+	return cells
+}
+
+func (g *Grid) countState(state CellState) int {
+	count := 0
+	for _, row := range g.locations {
+		for _, cell := range row {
+			if cell == state {
+				count++
+			}
+		}
+	}
+	return count
+}
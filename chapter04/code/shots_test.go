@@ -0,0 +1,185 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestShotCounters(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(0, 0, 3, Horizontal)
+
+	// Act
+	grid.Fire(0, 0)
+	grid.Fire(0, 2)
+	grid.Fire(5, 5)
+
+	// Assert
+	if got := grid.HitCount(); got != 2 {
+		t.Errorf("got %d hits, want 2", got)
+	}
+
+	if got := grid.MissCount(); got != 1 {
+		t.Errorf("got %d misses, want 1", got)
+	}
+
+	if got := grid.ShotCount(); got != 3 {
+		t.Errorf("got %d shots, want 3", got)
+	}
+}
+
+func TestShotCountersIgnoreRejectedShots(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShip(0, 0)
+	grid.Fire(0, 0)
+
+	// Act
+	grid.Fire(0, 0)
+	grid.Fire(-1, 0)
+
+	// Assert
+	if got := grid.ShotCount(); got != 1 {
+		t.Errorf("got %d shots, want 1", got)
+	}
+}
+
+func TestHitsAndMisses(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(2, 2, 3, Vertical)
+
+	// Act
+	grid.Fire(4, 2)
+	grid.Fire(6, 0)
+	grid.Fire(2, 2)
+	grid.Fire(0, 5)
+	grid.Fire(2, 2)
+
+	// Assert
+	wantHits := [][2]int{{2, 2}, {4, 2}}
+	if got := grid.Hits(); !reflect.DeepEqual(got, wantHits) {
+		t.Errorf("got hits %v, want %v", got, wantHits)
+	}
+
+	wantMisses := [][2]int{{0, 5}, {6, 0}}
+	if got := grid.Misses(); !reflect.DeepEqual(got, wantMisses) {
+		t.Errorf("got misses %v, want %v", got, wantMisses)
+	}
+
+	unfired := [2]int{3, 2}
+	for _, cell := range append(grid.Hits(), grid.Misses()...) {
+		if cell == unfired {
+			t.Errorf("unfired cell %v reported as shot at", unfired)
+		}
+	}
+}
+
+func TestFireSalvo(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(1, 1, 3, Horizontal)
+
+	// Act
+	hits, err := grid.FireSalvo([][2]int{{1, 1}, {5, 5}, {1, 3}})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []bool{true, false, true}
+	if !reflect.DeepEqual(hits, want) {
+		t.Errorf("got %v, want %v", hits, want)
+	}
+
+	if got := grid.ShotCount(); got != 3 {
+		t.Errorf("got %d shots recorded, want 3", got)
+	}
+}
+
+func TestFireSalvoRejectsWholeSalvo(t *testing.T) {
+	tests := []struct {
+		name  string
+		salvo [][2]int
+		want  error
+		index string
+	}{
+		{"off the grid", [][2]int{{1, 1}, {5, 5}, {7, 0}}, ErrOutOfBounds, "salvo shot 2"},
+		{"already fired", [][2]int{{1, 1}, {0, 0}}, ErrAlreadyFired, "salvo shot 1"},
+		{"repeated in salvo", [][2]int{{1, 2}, {1, 2}}, ErrAlreadyFired, "salvo shot 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			grid := NewGrid()
+			grid.PlaceShipAt(1, 1, 3, Horizontal)
+			grid.Fire(0, 0)
+
+			// Act
+			hits, err := grid.FireSalvo(tt.salvo)
+
+			// Assert
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("got error %v, want %v", err, tt.want)
+			}
+
+			if !strings.Contains(err.Error(), tt.index) {
+				t.Errorf("got error %q, want it to name %s", err, tt.index)
+			}
+
+			if hits != nil {
+				t.Errorf("got results %v for a rejected salvo", hits)
+			}
+
+			if got := grid.ShotCount(); got != 1 {
+				t.Errorf("got %d shots recorded, want only the earlier shot", got)
+			}
+		})
+	}
+}
+
+func TestAvailableTargetsShrinkWithEachShot(t *testing.T) {
+	// Arrange
+	grid, _ := NewGridWithSize(4, 5)
+	grid.PlaceShipAt(1, 1, 3, Horizontal)
+
+	// Act
+	initial := len(grid.AvailableTargets())
+	grid.Fire(1, 2)
+	afterHit := len(grid.AvailableTargets())
+	grid.Fire(3, 4)
+	afterMiss := len(grid.AvailableTargets())
+	grid.Fire(3, 4)
+	afterRepeat := len(grid.AvailableTargets())
+
+	// Assert
+	if initial != 20 || afterHit != 19 || afterMiss != 18 || afterRepeat != 18 {
+		t.Errorf("got %d, %d, %d, %d targets, want 20, 19, 18, 18", initial, afterHit, afterMiss, afterRepeat)
+	}
+
+	for _, cell := range grid.AvailableTargets() {
+		if cell == [2]int{1, 2} || cell == [2]int{3, 4} {
+			t.Errorf("fired cell %v still available", cell)
+		}
+	}
+}
+
+func TestAvailableTargetsIncludeHiddenShips(t *testing.T) {
+	// Arrange
+	grid, _ := NewGridWithSize(1, 3)
+	grid.PlaceShip(0, 1)
+
+	// Act
+	got := grid.AvailableTargets()
+
+	// Assert
+	want := [][2]int{{0, 0}, {0, 1}, {0, 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
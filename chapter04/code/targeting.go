@@ -0,0 +1,112 @@
+package main
+
+// TargetDensity counts, for every cell, the ways a ship of one of the
+// remaining lengths could lie across it given the shots fired so far. Ships
+// cannot lie across a miss or a sunk ship. While any hit belongs to a ship
+// that is still afloat, only positions covering such a hit are counted, which
+// concentrates the density around it. Cells already fired at score zero, and
+// lengths below one are ignored.
+func (g *Grid) TargetDensity(remaining []int) [][]int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return g.targetDensity(remaining)
+}
+
+func (g *Grid) targetDensity(remaining []int) [][]int {
+	density := makeBoard[int](g.rows, g.cols)
+
+	var openHits bool
+	for row := range g.locations {
+		for col := range g.locations[row] {
+			if g.locations[row][col] == Hit && !g.isSunk(g.shipIDs[row][col]) {
+				openHits = true
+			}
+		}
+	}
+
+	for _, length := range remaining {
+		if length < 1 {
+			continue
+		}
+
+		orientations := []Orientation{Horizontal, Vertical}
+		if length == 1 {
+			orientations = orientations[:1]
+		}
+
+		for row := 0; row < g.rows; row++ {
+			for col := 0; col < g.cols; col++ {
+				for _, orientation := range orientations {
+					cells := shipLayout(row, col, length, orientation)
+					if !g.couldHoldShip(cells, openHits) {
+						continue
+					}
+
+					for _, cell := range cells {
+						if !g.fired(cell[0], cell[1]) {
+							density[cell[0]][cell[1]]++
+						}
+					}
+				}
+			}
+		}
+	}
+	return density
+}
+
+// HeatmapString renders the board with each cell's TargetDensity for the
+// ships still afloat, scaled to a digit from 0 to 9. Only the hottest cells
+// show 9, any cell a ship could still cover shows at least 1, and cells that
+// have been fired at show 0.
+func (g *Grid) HeatmapString() string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var remaining []int
+	for id, cells := range g.ships {
+		if !g.isSunk(id) {
+			remaining = append(remaining, len(cells))
+		}
+	}
+
+	density := g.targetDensity(remaining)
+	peak := 0
+	for _, row := range density {
+		for _, value := range row {
+			peak = max(peak, value)
+		}
+	}
+
+	return g.render(func(row int, col int) byte {
+		if peak == 0 {
+			return '0'
+		}
+		return byte('0' + (density[row][col]*9+peak-1)/peak)
+	})
+}
+
+func (g *Grid) couldHoldShip(cells [][2]int, mustCoverHit bool) bool {
+	coversHit := false
+	for _, cell := range cells {
+		if !g.inBounds(cell[0], cell[1]) {
+			return false
+		}
+
+		switch g.locations[cell[0]][cell[1]] {
+		case Miss:
+			return false
+		case Hit:
+			if g.isSunk(g.shipIDs[cell[0]][cell[1]]) {
+				return false
+			}
+			coversHit = true
+		}
+	}
+	return coversHit || !mustCoverHit
+}
+
+func (g *Grid) fired(row int, col int) bool {
+	state := g.locations[row][col]
+	return state == Hit || state == Miss
+}
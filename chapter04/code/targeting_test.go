@@ -0,0 +1,149 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTargetDensityOnEmptyBoardPeaksInCentre(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+
+	// Act
+	density := grid.TargetDensity([]int{3})
+
+	// Assert
+	if density[3][3] <= density[0][0] {
+		t.Errorf("centre scored %d, corner %d; want centre higher", density[3][3], density[0][0])
+	}
+
+	if density[0][0] != 2 {
+		t.Errorf("corner scored %d, want 2", density[0][0])
+	}
+}
+
+func TestTargetDensityPeaksAroundIsolatedHit(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(3, 2, 3, Horizontal)
+	grid.Fire(3, 3)
+
+	// Act
+	density := grid.TargetDensity([]int{3})
+
+	// Assert
+	if density[3][3] != 0 {
+		t.Errorf("fired cell scored %d, want 0", density[3][3])
+	}
+
+	peak := 0
+	for _, row := range density {
+		for _, value := range row {
+			if value > peak {
+				peak = value
+			}
+		}
+	}
+
+	for _, cell := range [][2]int{{2, 3}, {4, 3}, {3, 2}, {3, 4}} {
+		if density[cell[0]][cell[1]] != peak {
+			t.Errorf("neighbour %v scored %d, want the peak %d", cell, density[cell[0]][cell[1]], peak)
+		}
+	}
+
+	for _, cell := range [][2]int{{0, 0}, {2, 2}, {6, 6}} {
+		if density[cell[0]][cell[1]] != 0 {
+			t.Errorf("cell %v out of reach of the hit scored %d, want 0", cell, density[cell[0]][cell[1]])
+		}
+	}
+}
+
+func TestTargetDensityAvoidsMissesAndSunkShips(t *testing.T) {
+	// Arrange
+	grid, _ := NewGridWithSize(1, 5)
+	grid.PlaceShipAt(0, 0, 2, Horizontal)
+	grid.Fire(0, 0)
+	grid.Fire(0, 1)
+	grid.Fire(0, 3)
+
+	// Act
+	density := grid.TargetDensity([]int{2})
+
+	// Assert
+	for col, value := range density[0] {
+		if value != 0 {
+			t.Errorf("column %d scored %d, want 0 as no ship of length 2 fits", col, value)
+		}
+	}
+}
+
+func TestHeatmapString(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	grid.PlaceShipAt(3, 2, 3, Horizontal)
+	grid.Fire(3, 3)
+	grid.Fire(0, 0)
+
+	// Act
+	lines := strings.Split(strings.TrimSuffix(grid.HeatmapString(), "\n"), "\n")
+
+	// Assert
+	if len(lines) != ROWS+1 {
+		t.Fatalf("got %d lines, want %d", len(lines), ROWS+1)
+	}
+
+	for _, line := range lines[1:] {
+		if len(strings.Fields(line)) != COLUMNS+1 {
+			t.Errorf("row %q does not have %d cells", line, COLUMNS)
+		}
+	}
+
+	cell := func(row int, col int) byte {
+		return strings.Fields(lines[row+1])[col+1][0]
+	}
+
+	if cell(2, 3) != '9' {
+		t.Errorf("got %c next to the hit, want 9", cell(2, 3))
+	}
+
+	if cell(3, 3) != '0' || cell(0, 0) != '0' {
+		t.Errorf("got %c and %c for fired cells, want 0", cell(3, 3), cell(0, 0))
+	}
+
+	if cell(6, 6) != '0' {
+		t.Errorf("got %c far from the hit, want 0", cell(6, 6))
+	}
+
+	if cell(3, 5) <= cell(6, 6) || cell(3, 5) >= cell(2, 3) {
+		t.Errorf("got %c two cells from the hit, want it between the far and near cells", cell(3, 5))
+	}
+}
+
+func TestHeatmapStringOnEmptyGrid(t *testing.T) {
+	// Arrange
+	grid, _ := NewGridWithSize(2, 3)
+
+	// Act
+	got := grid.HeatmapString()
+
+	// Assert
+	want := "  A B C\n1 0 0 0\n2 0 0 0\n"
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTargetDensityIgnoresInvalidLengths(t *testing.T) {
+	// Arrange
+	grid := NewGrid()
+	want := grid.TargetDensity([]int{3})
+
+	// Act
+	got := grid.TargetDensity([]int{-1, 0, 3})
+
+	// Assert
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want the density for the valid length only %v", got, want)
+	}
+}